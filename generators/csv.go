@@ -0,0 +1,206 @@
+package generators
+
+import (
+	"encoding"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+
+	. "github.com/rushsteve1/fp"
+	"github.com/rushsteve1/fp/monads"
+)
+
+// CSVOptions configures the underlying [csv.Reader], see its documentation.
+// The zero value uses the same defaults as [csv.NewReader].
+type CSVOptions struct {
+	Comma            rune
+	Comment          rune
+	FieldsPerRecord  int
+	LazyQuotes       bool
+	TrimLeadingSpace bool
+}
+
+func newCSVReader(r io.Reader, opts []CSVOptions) *csv.Reader {
+	cr := csv.NewReader(r)
+	if len(opts) > 0 {
+		o := opts[0]
+		cr.Comma = Or(o.Comma, cr.Comma)
+		cr.Comment = o.Comment
+		cr.FieldsPerRecord = o.FieldsPerRecord
+		cr.LazyQuotes = o.LazyQuotes
+		cr.TrimLeadingSpace = o.TrimLeadingSpace
+	}
+	return cr
+}
+
+// CSV yields every record from the reader, the options are optional.
+// Malformed records are yielded as errors and the rest are still read,
+// but any other error stops the sequence.
+// See its counterpart [transducers.WriteCSV]
+func CSV(r io.Reader, opts ...CSVOptions) Seq[monads.Result[[]string]] {
+	return SeqFunc[monads.Result[[]string]](func(yield func(monads.Result[[]string]) bool) {
+		cr := newCSVReader(r, opts)
+		for {
+			rec, err := cr.Read()
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if !yield(monads.Wrap(rec, err)) {
+				return
+			}
+			var parseErr *csv.ParseError
+			if err != nil && !errors.As(err, &parseErr) {
+				return
+			}
+		}
+	})
+}
+
+// CSVInto is like [CSV] but maps each record onto a new struct T.
+// The first record is the header, and each column is matched to the field
+// with the same `csv` tag, or otherwise the same name ignoring case.
+// Fields tagged `csv:"-"` and columns with no matching field are ignored.
+// Embedded struct pointers are allocated when one of their fields is set,
+// but fields behind an unexported embedded pointer are ignored.
+//
+// Fields can be strings, bools, numbers, or implement
+// [encoding.TextUnmarshaler]. A value that fails to parse is yielded as an
+// error for that record.
+func CSVInto[T any](r io.Reader, opts ...CSVOptions) Seq[monads.Result[T]] {
+	return SeqFunc[monads.Result[T]](func(yield func(monads.Result[T]) bool) {
+		var fields [][]int
+		var header []string
+		n := 0
+		CSV(r, opts...).Seq(func(res monads.Result[[]string]) bool {
+			n++
+			if res.Err != nil {
+				return yield(monads.Result[T]{Err: res.Err})
+			}
+			if fields == nil {
+				header = res.V
+				var err error
+				fields, err = csvFields[T](header)
+				if err != nil {
+					yield(monads.Result[T]{Err: err})
+					return false
+				}
+				return true
+			}
+
+			var t T
+			v := reflect.ValueOf(&t).Elem()
+			for i, col := range res.V {
+				if i >= len(fields) || fields[i] == nil {
+					continue
+				}
+				if err := setCSVField(csvField(v, fields[i]), col); err != nil {
+					err = fmt.Errorf("record %d column %q: %w", n, header[i], err)
+					return yield(monads.Result[T]{Err: err})
+				}
+			}
+			return yield(monads.Wrap(t, nil))
+		})
+	})
+}
+
+// csvFields returns the index of the matching field for each column
+func csvFields[T any](header []string) ([][]int, error) {
+	rt := reflect.TypeFor[T]()
+	if rt.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("CSVInto type must be a struct, got %s", rt)
+	}
+
+	byName := make(map[string][]int)
+	for _, f := range reflect.VisibleFields(rt) {
+		if !f.IsExported() || f.Anonymous || !csvSettable(rt, f.Index) {
+			continue
+		}
+		name, ok := f.Tag.Lookup("csv")
+		if name == "-" {
+			continue
+		}
+		if !ok || name == "" {
+			name = f.Name
+		}
+		byName[strings.ToLower(name)] = f.Index
+	}
+
+	out := make([][]int, len(header))
+	for i, col := range header {
+		out[i] = byName[strings.ToLower(strings.TrimSpace(col))]
+	}
+	return out, nil
+}
+
+// csvSettable reports if the field can be reached without going through an
+// unexported embedded pointer, which can't be allocated
+func csvSettable(rt reflect.Type, index []int) bool {
+	for _, i := range index[:len(index)-1] {
+		f := rt.Field(i)
+		rt = f.Type
+		if rt.Kind() == reflect.Pointer {
+			if !f.IsExported() {
+				return false
+			}
+			rt = rt.Elem()
+		}
+	}
+	return true
+}
+
+// csvField is like [reflect.Value.FieldByIndex] but allocates nil embedded
+// pointers along the way
+func csvField(v reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v
+}
+
+func setCSVField(v reflect.Value, s string) error {
+	if u, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return u.UnmarshalText([]byte(s))
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported field type %s", v.Type())
+	}
+	return nil
+}
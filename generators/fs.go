@@ -0,0 +1,148 @@
+package generators
+
+import (
+	"io/fs"
+	"os"
+	"path"
+
+	. "github.com/rushsteve1/fp"
+)
+
+// WalkOptions configures [Walk], the zero value walks the entire tree
+// without following symbolic links.
+type WalkOptions struct {
+	// MaxDepth limits how far below the root entries are yielded,
+	// 1 only yields the direct children of root. Zero means no limit.
+	MaxDepth int
+	// FollowSymlinks descends into symbolic links to directories.
+	// Links to a directory that is already being walked are yielded but not
+	// descended into, which stops symbolic link loops. File systems that
+	// don't report file identity, see [os.SameFile], instead stop after
+	// following 40 links along a single path.
+	FollowSymlinks bool
+	// Skip is called for every entry, entries it returns true for are not
+	// yielded and are not descended into if they are directories
+	Skip func(path string, d fs.DirEntry) bool
+	// OnError is called with any error reading the tree, returning false stops
+	// the walk. By default errors are passed to [Check].
+	OnError func(path string, err error) bool
+}
+
+const maxSymlinks = 40
+
+// Walk yields the path and entry of every file and directory in the tree,
+// starting with root itself. Entries are yielded in lexical order,
+// each directory before its contents. The options are optional.
+func Walk(fsys fs.FS, root string, opts ...WalkOptions) Seq2[string, fs.DirEntry] {
+	var o WalkOptions
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+	onError := func(p string, err error) bool {
+		if o.OnError != nil {
+			return o.OnError(p, err)
+		}
+		Check(err)
+		return true
+	}
+
+	return Seq2Func[string, fs.DirEntry](func(yield func(string, fs.DirEntry) bool) {
+		info, err := fs.Stat(fsys, root)
+		if err != nil {
+			onError(root, err)
+			return
+		}
+		d := fs.FileInfoToDirEntry(info)
+		if o.Skip != nil && o.Skip(root, d) {
+			return
+		}
+		if !yield(root, d) || !d.IsDir() {
+			return
+		}
+
+		// The directories currently being walked, used to detect link loops
+		var parents []fs.FileInfo
+		inParents := func(info fs.FileInfo) bool {
+			for _, p := range parents {
+				if os.SameFile(info, p) {
+					return true
+				}
+			}
+			return false
+		}
+
+		// walk returns false if the walk should stop
+		var walk func(dir string, info fs.FileInfo, depth int, links int) bool
+		walk = func(dir string, info fs.FileInfo, depth int, links int) bool {
+			if o.MaxDepth > 0 && depth > o.MaxDepth {
+				return true
+			}
+			entries, err := fs.ReadDir(fsys, dir)
+			if err != nil {
+				return onError(dir, err)
+			}
+			parents = append(parents, info)
+			defer func() { parents = parents[:len(parents)-1] }()
+
+			for _, e := range entries {
+				p := path.Join(dir, e.Name())
+				if o.Skip != nil && o.Skip(p, e) {
+					continue
+				}
+				if !yield(p, e) {
+					return false
+				}
+
+				descend, nextLinks := e.IsDir(), links
+				var info fs.FileInfo
+				if o.FollowSymlinks {
+					var err error
+					if e.Type()&fs.ModeSymlink != 0 {
+						info, err = fs.Stat(fsys, p)
+						nextLinks++
+					} else if descend {
+						info, err = e.Info()
+					}
+					if err != nil {
+						if !onError(p, err) {
+							return false
+						}
+						continue
+					}
+					if info != nil {
+						descend = info.IsDir() && nextLinks <= maxSymlinks && !inParents(info)
+					}
+				}
+				if descend && !walk(p, info, depth+1, nextLinks) {
+					return false
+				}
+			}
+			return true
+		}
+		walk(root, info, 1, 0)
+	})
+}
+
+// ReadDir yields the path and entry of everything in the directory,
+// in lexical order. Errors are passed to [Check].
+func ReadDir(fsys fs.FS, dir string) Seq2[string, fs.DirEntry] {
+	return Seq2Func[string, fs.DirEntry](func(yield func(string, fs.DirEntry) bool) {
+		for _, e := range Must(fs.ReadDir(fsys, dir)) {
+			if !yield(path.Join(dir, e.Name()), e) {
+				return
+			}
+		}
+	})
+}
+
+// Glob yields the paths matching the pattern, see [fs.Glob].
+// Errors are passed to [Check].
+func Glob(fsys fs.FS, pattern string) Seq[string] {
+	return SeqFunc[string](func(yield func(string) bool) {
+		for _, p := range Must(fs.Glob(fsys, pattern)) {
+			if !yield(p) {
+				return
+			}
+		}
+	})
+}
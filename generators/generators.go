@@ -1,6 +1,8 @@
 package generators
 
 import (
+	"bytes"
+	"context"
 	"errors"
 	"io"
 	"math/rand/v2"
@@ -52,6 +54,69 @@ func Integers() Seq[int] {
 	})
 }
 
+// Range yields numbers from start up to but not including end, counting by
+// step which may be negative. A step of zero yields nothing.
+//
+// Floats are calculated as start + i*step rather than by repeated addition,
+// so they do not accumulate rounding errors. Integers stop before they would
+// overflow.
+func Range[T Numeric](start, end, step T) Seq[T] {
+	return SeqFunc[T](func(yield func(T) bool) {
+		var zero T
+		if step == zero {
+			return
+		}
+		done := func(v T) bool {
+			return step > zero && v >= end || step < zero && v <= end
+		}
+
+		// Only floats have a fractional part
+		if T(1)/T(2) != zero {
+			// The counter is an int since large float32s can't count by 1
+			for i := 0; ; i++ {
+				v := start + T(i)*step
+				if done(v) || !yield(v) {
+					return
+				}
+			}
+		}
+
+		for v := start; !done(v) && yield(v); {
+			next := v + step
+			// Wrapping around means end is past the limits of T
+			if step > zero && next <= v || step < zero && next >= v {
+				return
+			}
+			v = next
+		}
+	})
+}
+
+// Iterate yields seed, f(seed), f(f(seed)) and so on forever
+func Iterate[T any](seed T, f func(T) T) Seq[T] {
+	return SeqFunc[T](func(yield func(T) bool) {
+		for v := seed; yield(v); v = f(v) {
+		}
+	})
+}
+
+// Unfold is the opposite of [reducers.Reduce], building a sequence from a
+// state. Each call to f returns the next value and the next state, or false
+// to end the sequence.
+func Unfold[T, S any](seed S, f func(S) (T, S, bool)) Seq[T] {
+	return SeqFunc[T](func(yield func(T) bool) {
+		s := seed
+		for {
+			var v T
+			var ok bool
+			v, s, ok = f(s)
+			if !ok || !yield(v) {
+				return
+			}
+		}
+	})
+}
+
 // Shuffle returns an infinite sequence of random elements from pick
 func Shuffle[E ~[]T, T any](pick E) Seq[T] {
 	return SeqFunc[T](func(yield func(T) bool) {
@@ -75,6 +140,24 @@ func Ticker(d time.Duration) Seq[time.Time] {
 	})
 }
 
+// TickerCtx is like [Ticker] but stops when the context is cancelled
+func TickerCtx(ctx context.Context, d time.Duration) Seq[time.Time] {
+	return SeqFunc[time.Time](func(yield func(time.Time) bool) {
+		tick := time.NewTicker(d)
+		defer tick.Stop()
+		for {
+			select {
+			case t := <-tick.C:
+				if !yield(t) {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	})
+}
+
 // Chan returns an iterator that continually yields values from the channel.
 // The channel is closed if the sequence stops.
 // It is the caller's responsibility to close the channel to prevent deadlocks
@@ -89,17 +172,49 @@ func Chan[T any](c chan T) Seq[T] {
 	})
 }
 
+// ChanCtx is like [Chan] but stops when the context is cancelled.
+// Cancelling the context does not close the channel.
+func ChanCtx[T any](ctx context.Context, c chan T) Seq[T] {
+	return SeqFunc[T](func(yield func(T) bool) {
+		for {
+			select {
+			case t, ok := <-c:
+				if !ok {
+					return
+				}
+				if !yield(t) {
+					close(c)
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	})
+}
+
+// ReaderBufSize is the size of the buffer used by [Reader]
+var ReaderBufSize = 4096
+
 // Reader reads from the passed [io.Reader] turning into a sequence of byte arrays.
-// See its counterpart [transducers.Writer]
+// Each byte array is a new copy of at most [ReaderBufSize] bytes.
+// The sequence stops at [io.EOF] or after yielding any other error.
+// See its counterpart [transducers.Write]
 func Reader(r io.Reader) Seq[monads.Result[[]byte]] {
 	return SeqFunc[monads.Result[[]byte]](func(yield func(monads.Result[[]byte]) bool) {
-		var buf []byte
+		buf := make([]byte, ReaderBufSize)
 		for {
-			_, err := r.Read(buf)
+			n, err := r.Read(buf)
+			if n > 0 {
+				if !yield(monads.Wrap(bytes.Clone(buf[:n]), nil)) {
+					return
+				}
+			}
 			if errors.Is(err, io.EOF) {
 				return
 			}
-			if !yield(monads.Wrap(buf, err)) {
+			if err != nil {
+				yield(monads.Wrap[[]byte](nil, err))
 				return
 			}
 		}
@@ -119,3 +234,27 @@ func Accept(l net.Listener) Seq[net.Conn] {
 		}
 	})
 }
+
+// AcceptCtx is like [Accept] but stops when the context is cancelled.
+// The listener is closed when the context is cancelled in order to unblock
+// any pending call to Accept.
+func AcceptCtx(ctx context.Context, l net.Listener) Seq[net.Conn] {
+	return SeqFunc[net.Conn](func(yield func(net.Conn) bool) {
+		stop := context.AfterFunc(ctx, func() { l.Close() })
+		defer stop()
+		for {
+			c, err := l.Accept()
+			if ctx.Err() != nil {
+				if c != nil {
+					c.Close()
+				}
+				return
+			}
+			Check(err)
+			if !yield(c) {
+				l.Close()
+				return
+			}
+		}
+	})
+}
@@ -1,11 +1,23 @@
 package generators_test
 
 import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"testing/fstest"
+	"testing/iotest"
 	"time"
 
 	"github.com/rushsteve1/fp"
 	. "github.com/rushsteve1/fp/generators"
+	"github.com/rushsteve1/fp/monads"
 )
 
 func TestTicker(t *testing.T) {
@@ -37,3 +49,313 @@ func TestChan(t *testing.T) {
 
 	fp.AssertEq(t, i, 5)
 }
+
+func TestTickerCtx(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	i := 0
+	for range TickerCtx(ctx, 10*time.Millisecond).Seq {
+		i++
+		if i == 2 {
+			cancel()
+		}
+	}
+	// A tick may race with the cancel
+	fp.Assert(t, i == 2 || i == 3)
+}
+
+func TestChanCtx(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	c := make(chan int)
+	go func() {
+		for i := range 3 {
+			c <- i
+		}
+		cancel()
+	}()
+
+	i := 0
+	for range ChanCtx(ctx, c).Seq {
+		i++
+	}
+	fp.AssertEq(t, i, 3)
+}
+
+func TestAcceptCtx(t *testing.T) {
+	l := fp.Must(net.Listen("tcp", "127.0.0.1:0"))
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	for c := range AcceptCtx(ctx, l).Seq {
+		c.Close()
+	}
+	fp.Assert(t, ctx.Err() != nil)
+}
+
+func collect[T any](seq fp.Seq[monads.Result[T]]) (out []T, err error) {
+	for r := range seq.Seq {
+		if r.Err != nil {
+			return out, r.Err
+		}
+		out = append(out, r.V)
+	}
+	return out, nil
+}
+
+func TestReader(t *testing.T) {
+	old := ReaderBufSize
+	ReaderBufSize = 4
+	defer func() { ReaderBufSize = old }()
+
+	chunks, err := collect(Reader(strings.NewReader("hello world")))
+	fp.AssertEq(t, err, nil)
+	fp.AssertEq(t, len(chunks), 3)
+	fp.AssertEq(t, string(chunks[2]), "rld")
+}
+
+func TestLines(t *testing.T) {
+	lines, err := collect(Lines(strings.NewReader("one\ntwo\r\n\nthree")))
+	fp.AssertEq(t, err, nil)
+	fp.AssertSliceEq(t, lines, []string{"one", "two", "", "three"})
+
+	errBoom := errors.New("boom")
+	lines, err = collect(Lines(io.MultiReader(strings.NewReader("a\nb\n"), iotest.ErrReader(errBoom))))
+	fp.AssertSliceEq(t, lines, []string{"a", "b"})
+	fp.AssertEq(t, err, errBoom)
+}
+
+func TestScan(t *testing.T) {
+	words, _ := collect(Scan(strings.NewReader("the  quick\tfox"), bufio.ScanWords))
+	fp.AssertEq(t, len(words), 3)
+
+	parts, _ := collect(Delimited(strings.NewReader("a::b::::c"), []byte("::")))
+	fp.AssertEq(t, len(parts), 4)
+	fp.AssertEq(t, string(parts[3]), "c")
+
+	blocks, _ := collect(Blocks(strings.NewReader("abcdefg"), 3))
+	fp.AssertEq(t, len(blocks), 3)
+	fp.AssertEq(t, string(blocks[2]), "g")
+
+	_, err := collect(Delimited(strings.NewReader("abc"), nil))
+	fp.AssertEq(t, err, ErrEmptySeparator)
+	_, err = collect(Blocks(strings.NewReader("abc"), 0))
+	fp.AssertEq(t, err, ErrInvalidSize)
+}
+
+type event struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func TestJSONStream(t *testing.T) {
+	nd := "{\"id\": 1, \"name\": \"a\"}\n\n{\"id\": \"bad\"}\n{oops\n{\"id\": 3}\n"
+	var ids []int
+	errs := 0
+	for r := range JSONStream[event](strings.NewReader(nd)).Seq {
+		if r.Err != nil {
+			errs++
+			continue
+		}
+		ids = append(ids, r.V.ID)
+	}
+	fp.AssertSliceEq(t, ids, []int{1, 3})
+	fp.AssertEq(t, errs, 2)
+
+	arr := ` [{"id": 1}, {"id": "bad"}, {"id": 3}]`
+	ids = nil
+	errs = 0
+	for r := range JSONStream[event](strings.NewReader(arr)).Seq {
+		if r.Err != nil {
+			errs++
+			continue
+		}
+		ids = append(ids, r.V.ID)
+	}
+	fp.AssertSliceEq(t, ids, []int{1, 3})
+	fp.AssertEq(t, errs, 1)
+
+	evts, err := collect(JSONStream[event](strings.NewReader("")))
+	fp.AssertEq(t, len(evts), 0)
+	fp.AssertEq(t, err, nil)
+
+	long := `{"id": 1}` + "\n" + `{"id": 2, "name": "` + strings.Repeat("x", 70000) + `"}` + "\n" + `{"id": 3}`
+	evts, err = collect(JSONStream[event](strings.NewReader(long)))
+	fp.AssertEq(t, err, nil)
+	fp.AssertEq(t, len(evts), 3)
+	fp.AssertEq(t, len(evts[1].Name), 70000)
+	fp.AssertEq(t, evts[2].ID, 3)
+
+	pairs, err := collect(JSONStream[[]int](strings.NewReader("[1,2]\n[3,4]\n\n[5,6]\n")))
+	fp.AssertEq(t, err, nil)
+	fp.AssertEq(t, len(pairs), 3)
+	fp.AssertSliceEq(t, pairs[2], []int{5, 6})
+
+	nums, err := collect(JSONStream[int](strings.NewReader("[1, 2, 3]\n\n")))
+	fp.AssertEq(t, err, nil)
+	fp.AssertSliceEq(t, nums, []int{1, 2, 3})
+
+	nums, err = collect(JSONStream[int](strings.NewReader("[\n1,\n2\n]\n{}")))
+	fp.AssertSliceEq(t, nums, []int{1, 2})
+	fp.Assert(t, errors.Is(err, ErrTrailingJSON))
+}
+
+type row struct {
+	Name  string
+	Age   int     `csv:"years"`
+	Score float64 `csv:"score"`
+	Skip  string  `csv:"-"`
+}
+
+func TestCSV(t *testing.T) {
+	data := "name;years;score;extra\nann;30;1.5;x\nbob;old;2;y\nba\"d;1;1;z\ncat;40;3;z\n"
+	recs := 0
+	for r := range CSV(strings.NewReader(data), CSVOptions{Comma: ';'}).Seq {
+		if r.Err == nil {
+			recs++
+		}
+	}
+	fp.AssertEq(t, recs, 4)
+
+	var rows []row
+	errs := 0
+	for r := range CSVInto[row](strings.NewReader(data), CSVOptions{Comma: ';'}).Seq {
+		if r.Err != nil {
+			errs++
+			continue
+		}
+		rows = append(rows, r.V)
+	}
+	fp.AssertEq(t, errs, 2)
+	fp.AssertEq(t, len(rows), 2)
+	fp.AssertEq(t, rows[0], row{Name: "ann", Age: 30, Score: 1.5})
+	fp.AssertEq(t, rows[1].Name, "cat")
+
+	type embedded struct {
+		*row
+		ID int
+	}
+	embeds, err := collect(CSVInto[embedded](strings.NewReader("id,name\n1,ann\n")))
+	fp.AssertEq(t, err, nil)
+	fp.AssertEq(t, embeds[0].ID, 1)
+	fp.AssertEq(t, embeds[0].row, nil)
+
+	type Row = row
+	type exported struct {
+		*Row
+		ID int
+	}
+	exps, err := collect(CSVInto[exported](strings.NewReader("id,name\n1,ann\n")))
+	fp.AssertEq(t, err, nil)
+	fp.AssertEq(t, exps[0].Name, "ann")
+}
+
+func walkPaths(seq fp.Seq2[string, fs.DirEntry]) (out []string) {
+	for p := range seq.Seq2 {
+		out = append(out, p)
+	}
+	return out
+}
+
+func TestWalk(t *testing.T) {
+	fsys := fstest.MapFS{
+		"a/b/c.txt":   {},
+		"a/d.txt":     {},
+		"a/.git/head": {},
+		"e.go":        {},
+	}
+
+	fp.AssertSliceEq(t, walkPaths(Walk(fsys, ".")), []string{
+		".", "a", "a/.git", "a/.git/head", "a/b", "a/b/c.txt", "a/d.txt", "e.go",
+	})
+
+	fp.AssertSliceEq(t, walkPaths(Walk(fsys, ".", WalkOptions{
+		MaxDepth: 2,
+		Skip: func(p string, d fs.DirEntry) bool {
+			return d.IsDir() && strings.HasPrefix(d.Name(), ".") && p != "."
+		},
+	})), []string{".", "a", "a/b", "a/d.txt", "e.go"})
+
+	fp.AssertSliceEq(t, walkPaths(ReadDir(fsys, "a")), []string{"a/.git", "a/b", "a/d.txt"})
+
+	var globs []string
+	for p := range Glob(fsys, "a/*.txt").Seq {
+		globs = append(globs, p)
+	}
+	fp.AssertSliceEq(t, globs, []string{"a/d.txt"})
+
+	errs := 0
+	walkPaths(Walk(fsys, "missing", WalkOptions{
+		OnError: func(string, error) bool { errs++; return true },
+	}))
+	fp.AssertEq(t, errs, 1)
+}
+
+func TestWalkSymlinks(t *testing.T) {
+	dir := t.TempDir()
+	fp.Check(os.MkdirAll(filepath.Join(dir, "a", "b"), 0o755))
+	fp.Check(os.WriteFile(filepath.Join(dir, "a", "b", "c.txt"), nil, 0o644))
+	for link, target := range map[string]string{
+		"link":   filepath.Join("a", "b"),
+		"a/up":   "..",
+		"a/here": ".",
+	} {
+		if err := os.Symlink(target, filepath.Join(dir, link)); err != nil {
+			t.Skip("symlinks not supported:", err)
+		}
+	}
+	fsys := os.DirFS(dir)
+
+	fp.AssertSliceEq(t, walkPaths(Walk(fsys, ".")), []string{
+		".", "a", "a/b", "a/b/c.txt", "a/here", "a/up", "link",
+	})
+
+	// Links back to a directory being walked are not descended into
+	fp.AssertSliceEq(t, walkPaths(Walk(fsys, ".", WalkOptions{FollowSymlinks: true})), []string{
+		".", "a", "a/b", "a/b/c.txt", "a/here", "a/up", "link", "link/c.txt",
+	})
+}
+
+func collectN[T any](seq fp.Seq[T], n int) (out []T) {
+	for v := range seq.Seq {
+		if len(out) == n {
+			break
+		}
+		out = append(out, v)
+	}
+	return out
+}
+
+func TestRange(t *testing.T) {
+	fp.AssertSliceEq(t, collectN(Range(0, 10, 3), 10), []int{0, 3, 6, 9})
+	fp.AssertSliceEq(t, collectN(Range(5, 0, -2), 10), []int{5, 3, 1})
+	fp.AssertSliceEq(t, collectN(Range(0, 0.5, 0.1), 10), []float64{0, 0.1, 0.2, 0.30000000000000004, 0.4})
+	fp.AssertSliceEq(t, collectN(Range[int8](-100, 100, 50), 10), []int8{-100, -50, 0, 50})
+	fp.AssertSliceEq(t, collectN(Range[int8](0, 127, 50), 10), []int8{0, 50, 100})
+	fp.AssertSliceEq(t, collectN(Range[int8](0, -128, -50), 10), []int8{0, -50, -100})
+	fp.AssertSliceEq(t, collectN(Range[uint8](0, 255, 100), 10), []uint8{0, 100, 200})
+	fp.AssertSliceEq(t, collectN(Range[uint8](250, 0, 255), 10), []uint8{})
+	fp.AssertSliceEq(t, collectN(Range[uint8](1, 255, 254), 10), []uint8{1})
+
+	n := 0
+	for range Range[float32](0, 2e7, 1).Seq {
+		n++
+	}
+	fp.Assert(t, n >= 2e7-2 && n <= 2e7)
+	fp.AssertEq(t, len(collectN(Range(0, 10, 0), 10)), 0)
+	fp.AssertEq(t, len(collectN(Range[uint](5, 0, 1), 10)), 0)
+}
+
+func TestIterate(t *testing.T) {
+	fp.AssertSliceEq(t, collectN(Iterate(1, func(x int) int { return x * 2 }), 5), []int{1, 2, 4, 8, 16})
+}
+
+func TestUnfold(t *testing.T) {
+	fib := Unfold([2]int{0, 1}, func(s [2]int) (int, [2]int, bool) {
+		return s[0], [2]int{s[1], s[0] + s[1]}, true
+	})
+	fp.AssertSliceEq(t, collectN(fib, 7), []int{0, 1, 1, 2, 3, 5, 8})
+
+	countdown := Unfold(3, func(n int) (int, int, bool) {
+		return n, n - 1, n > 0
+	})
+	fp.AssertSliceEq(t, collectN(countdown, 10), []int{3, 2, 1})
+}
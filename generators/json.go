@@ -0,0 +1,152 @@
+package generators
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	. "github.com/rushsteve1/fp"
+	"github.com/rushsteve1/fp/monads"
+)
+
+// ErrTrailingJSON is yielded by [JSONStream] when there is more data after a
+// top-level array
+var ErrTrailingJSON = errors.New("unexpected data after JSON array")
+
+// JSONStream decodes a stream of JSON values from the reader.
+// If the stream starts with '[' it is treated as a single top-level array and
+// each element is yielded, otherwise it is treated as newline-delimited JSON.
+// The exception is when the first line is a whole array with more lines after
+// it, which is treated as newline-delimited JSON of arrays.
+// Any data after a top-level array is yielded as an error.
+//
+// Each record that fails to decode is yielded as an error.
+// For NDJSON the rest of the lines are still decoded, but a syntax error in
+// an array stops the sequence since the rest of the array can't be trusted.
+// See its counterpart [transducers.EncodeJSON]
+func JSONStream[T any](r io.Reader) Seq[monads.Result[T]] {
+	return SeqFunc[monads.Result[T]](func(yield func(monads.Result[T]) bool) {
+		br := bufio.NewReader(r)
+		first, err := peekNonSpace(br)
+		if errors.Is(err, io.EOF) {
+			return
+		}
+		if err != nil {
+			yield(monads.Result[T]{Err: err})
+			return
+		}
+		if first != '[' {
+			ndjson[T](br).Seq(yield)
+			return
+		}
+
+		line, err := br.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			yield(monads.Result[T]{Err: err})
+			return
+		}
+		rest := bufio.NewReader(io.MultiReader(bytes.NewReader(line), br))
+		if json.Valid(line) && moreNonSpace(br) {
+			ndjson[T](rest).Seq(yield)
+		} else {
+			jsonArray[T](rest).Seq(yield)
+		}
+	})
+}
+
+func isSpace(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\r' || b == '\n'
+}
+
+// peekNonSpace discards leading whitespace and returns the next byte
+func peekNonSpace(br *bufio.Reader) (byte, error) {
+	for {
+		b, err := br.Peek(1)
+		if err != nil {
+			return 0, err
+		}
+		if !isSpace(b[0]) {
+			return b[0], nil
+		}
+		br.ReadByte()
+	}
+}
+
+// moreNonSpace reports if anything other than whitespace is left to read,
+// without consuming it. A full buffer of whitespace counts as more.
+func moreNonSpace(br *bufio.Reader) bool {
+	for n := 1; n <= br.Size(); n++ {
+		b, err := br.Peek(n)
+		if err != nil {
+			return false
+		}
+		if !isSpace(b[n-1]) {
+			return true
+		}
+	}
+	return true
+}
+
+func jsonArray[T any](r io.Reader) Seq[monads.Result[T]] {
+	return SeqFunc[monads.Result[T]](func(yield func(monads.Result[T]) bool) {
+		dec := json.NewDecoder(r)
+		// Consume the opening bracket
+		if _, err := dec.Token(); err != nil {
+			yield(monads.Result[T]{Err: err})
+			return
+		}
+		for i := 0; dec.More(); i++ {
+			var t T
+			err := dec.Decode(&t)
+			if err != nil {
+				err = fmt.Errorf("element %d: %w", i, err)
+			}
+			if !yield(monads.Wrap(t, err)) {
+				return
+			}
+			// Type errors skip the value but anything else is unrecoverable
+			var typeErr *json.UnmarshalTypeError
+			if err != nil && !errors.As(err, &typeErr) {
+				return
+			}
+		}
+		if _, err := dec.Token(); err != nil {
+			yield(monads.Result[T]{Err: err})
+			return
+		}
+		// There should be nothing after the closing bracket
+		if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+			yield(monads.Result[T]{Err: errors.Join(ErrTrailingJSON, err)})
+		}
+	})
+}
+
+// ndjson reads whole lines so that records aren't limited to the
+// [bufio.Scanner] token size
+func ndjson[T any](br *bufio.Reader) Seq[monads.Result[T]] {
+	return SeqFunc[monads.Result[T]](func(yield func(monads.Result[T]) bool) {
+		for line := 1; ; line++ {
+			b, err := br.ReadBytes('\n')
+			if err != nil && !errors.Is(err, io.EOF) {
+				yield(monads.Result[T]{Err: err})
+				return
+			}
+			if len(bytes.TrimSpace(b)) > 0 {
+				var t T
+				uerr := json.Unmarshal(b, &t)
+				if uerr != nil {
+					uerr = fmt.Errorf("line %d: %w", line, uerr)
+				}
+				if !yield(monads.Wrap(t, uerr)) {
+					return
+				}
+			}
+			if err != nil {
+				return
+			}
+		}
+	})
+}
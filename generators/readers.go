@@ -0,0 +1,83 @@
+package generators
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+
+	. "github.com/rushsteve1/fp"
+	"github.com/rushsteve1/fp/monads"
+)
+
+// These generators split an [io.Reader] into tokens using [bufio.Scanner].
+// Each token is a new copy, and a read error is yielded as the final element.
+
+var (
+	ErrEmptySeparator = errors.New("Delimited separator is empty")
+	ErrInvalidSize    = errors.New("Blocks size is not positive")
+)
+
+// Scan yields the tokens produced by the split function
+func Scan(r io.Reader, split bufio.SplitFunc) Seq[monads.Result[[]byte]] {
+	return scanner(r, split, bufio.MaxScanTokenSize)
+}
+
+func scanner(r io.Reader, split bufio.SplitFunc, size int) Seq[monads.Result[[]byte]] {
+	return SeqFunc[monads.Result[[]byte]](func(yield func(monads.Result[[]byte]) bool) {
+		sc := bufio.NewScanner(r)
+		sc.Buffer(make([]byte, 0, min(size, 4096)), max(size, bufio.MaxScanTokenSize))
+		sc.Split(split)
+		for sc.Scan() {
+			if !yield(monads.Wrap(bytes.Clone(sc.Bytes()), nil)) {
+				return
+			}
+		}
+		if err := sc.Err(); err != nil {
+			yield(monads.Wrap[[]byte](nil, err))
+		}
+	})
+}
+
+// Lines yields each line without the line ending, see [bufio.ScanLines]
+func Lines(r io.Reader) Seq[monads.Result[string]] {
+	return SeqFunc[monads.Result[string]](func(yield func(monads.Result[string]) bool) {
+		Scan(r, bufio.ScanLines).Seq(func(res monads.Result[[]byte]) bool {
+			return yield(monads.Wrap(string(res.V), res.Err))
+		})
+	})
+}
+
+// Delimited yields the bytes between each occurence of sep, not including sep.
+// An empty sep yields [ErrEmptySeparator].
+func Delimited(r io.Reader, sep []byte) Seq[monads.Result[[]byte]] {
+	if len(sep) == 0 {
+		return Once(monads.Wrap[[]byte](nil, ErrEmptySeparator))
+	}
+	return Scan(r, func(data []byte, atEOF bool) (int, []byte, error) {
+		if i := bytes.Index(data, sep); i >= 0 {
+			return i + len(sep), data[:i], nil
+		}
+		if atEOF && len(data) > 0 {
+			return len(data), data, nil
+		}
+		return 0, nil, nil
+	})
+}
+
+// Blocks yields blocks of size bytes, the last of which may be shorter.
+// A size less than 1 yields [ErrInvalidSize].
+func Blocks(r io.Reader, size int) Seq[monads.Result[[]byte]] {
+	if size < 1 {
+		return Once(monads.Wrap[[]byte](nil, ErrInvalidSize))
+	}
+	return scanner(r, func(data []byte, atEOF bool) (int, []byte, error) {
+		if len(data) >= size {
+			return size, data[:size], nil
+		}
+		if atEOF && len(data) > 0 {
+			return len(data), data, nil
+		}
+		return 0, nil, nil
+	}, size)
+}
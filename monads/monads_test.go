@@ -6,7 +6,7 @@ import (
 	"github.com/rushsteve1/fp"
 	. "github.com/rushsteve1/fp/fun"
 	. "github.com/rushsteve1/fp/monads"
-	. "github.com/rushsteve1/fp/reducers"
+	"github.com/rushsteve1/fp/reducers"
 	. "github.com/rushsteve1/fp/transducers"
 )
 
@@ -28,7 +28,7 @@ func TestMonadTransducer(t *testing.T) {
 	a := Transduce(
 		Some(10),
 		Curry2(Map, func(x int) int { return x * 2 }),
-		Chain2(First[int], Some),
+		Chain2(reducers.First[int], Some),
 	)
 
 	fp.AssertEq(t, a, Some(20))
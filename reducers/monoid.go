@@ -0,0 +1,144 @@
+package reducers
+
+import (
+	"sync"
+
+	. "github.com/rushsteve1/fp"
+)
+
+// ParallelChunkSize is the number of elements each goroutine of
+// [ParallelReduce] reduces at a time
+var ParallelChunkSize = 1024
+
+// Monoid is an associative Combine function along with its Identity.
+// Since Combine is associative the order of combining does not matter, only
+// the order of the elements, which makes it safe to reduce in parallel.
+//
+// Combine may modify and return its first argument, which is always an
+// accumulator that was originally created by Identity.
+type Monoid[T any] struct {
+	// Identity is a function so that each accumulator can be a new value,
+	// which is necessary for slices and maps
+	Identity func() T
+	Combine  func(T, T) T
+}
+
+// SumMonoid adds numbers together
+func SumMonoid[T Numeric]() Monoid[T] {
+	return Monoid[T]{
+		Identity: func() (out T) { return out },
+		Combine:  func(a, b T) T { return a + b },
+	}
+}
+
+// ProductMonoid multiplies numbers together
+func ProductMonoid[T Numeric]() Monoid[T] {
+	return Monoid[T]{
+		Identity: func() T { return 1 },
+		Combine:  func(a, b T) T { return a * b },
+	}
+}
+
+// MinMonoid finds the minimum, top must be greater than or equal to every
+// possible value such as [math.MaxInt]
+func MinMonoid[T Ordered](top T) Monoid[T] {
+	return Monoid[T]{
+		Identity: func() T { return top },
+		Combine:  func(a, b T) T { return min(a, b) },
+	}
+}
+
+// MaxMonoid finds the maximum, bottom must be less than or equal to every
+// possible value such as [math.MinInt]
+func MaxMonoid[T Ordered](bottom T) Monoid[T] {
+	return Monoid[T]{
+		Identity: func() T { return bottom },
+		Combine:  func(a, b T) T { return max(a, b) },
+	}
+}
+
+// ConcatMonoid appends slices together
+func ConcatMonoid[T any]() Monoid[[]T] {
+	return Monoid[[]T]{
+		Identity: func() []T { return nil },
+		Combine:  func(a, b []T) []T { return append(a, b...) },
+	}
+}
+
+// MergeMonoid merges maps together, using f to combine the values of keys
+// that are in both. If f is nil the later value is kept.
+func MergeMonoid[K comparable, V any](f func(V, V) V) Monoid[map[K]V] {
+	return Monoid[map[K]V]{
+		Identity: func() map[K]V { return make(map[K]V) },
+		Combine: func(a, b map[K]V) map[K]V {
+			for k, v := range b {
+				if old, ok := a[k]; ok && f != nil {
+					v = f(old, v)
+				}
+				a[k] = v
+			}
+			return a
+		},
+	}
+}
+
+// Fold is [Reduce] using a [Monoid]
+func Fold[T any](seq Seq[T], m Monoid[T]) T {
+	return Reduce(seq, m.Identity(), func(t T, acc T) T {
+		return m.Combine(acc, t)
+	})
+}
+
+// ParallelReduce is like [Fold] but reduces chunks of [ParallelChunkSize]
+// elements on up to workers goroutines, then combines the results in order.
+// The sequence itself is still consumed on the calling goroutine.
+func ParallelReduce[T any](seq Seq[T], m Monoid[T], workers int) T {
+	type chunk struct {
+		i  int
+		vs []T
+	}
+	chunks := make(chan chunk)
+
+	var mu sync.Mutex
+	partials := make(map[int]T)
+
+	var wg sync.WaitGroup
+	for range max(workers, 1) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for c := range chunks {
+				acc := m.Identity()
+				for _, v := range c.vs {
+					acc = m.Combine(acc, v)
+				}
+				mu.Lock()
+				partials[c.i] = acc
+				mu.Unlock()
+			}
+		}()
+	}
+
+	n := 0
+	buf := make([]T, 0, ParallelChunkSize)
+	for v := range seq.Seq {
+		buf = append(buf, v)
+		if len(buf) == ParallelChunkSize {
+			chunks <- chunk{n, buf}
+			buf = make([]T, 0, ParallelChunkSize)
+			n++
+		}
+	}
+	if len(buf) > 0 {
+		chunks <- chunk{n, buf}
+		n++
+	}
+	close(chunks)
+	wg.Wait()
+
+	out := m.Identity()
+	for i := range n {
+		out = m.Combine(out, partials[i])
+	}
+	return out
+}
@@ -0,0 +1,78 @@
+package reducers
+
+import (
+	"cmp"
+
+	. "github.com/rushsteve1/fp"
+	"github.com/rushsteve1/fp/monads"
+)
+
+// These reducers return a [monads.Option] that is only valid if the sequence
+// had any elements. They stop as early as possible and never collect the
+// sequence, so they are safe to use with one-shot sequences.
+
+// FirstOpt returns the first element of a sequence.
+// It stops immediately so it can be used with infinite sequences.
+func FirstOpt[T any](seq Seq[T]) monads.Option[T] {
+	for v := range seq.Seq {
+		return monads.Some(v)
+	}
+	return monads.None[T]()
+}
+
+// LastOpt returns the last element of a sequence
+func LastOpt[T any](seq Seq[T]) monads.Option[T] {
+	out := monads.None[T]()
+	for v := range seq.Seq {
+		out = monads.Some(v)
+	}
+	return out
+}
+
+// MaxBy returns the maximum element according to the comparison function.
+// If there are multiple maximums the first is returned.
+func MaxBy[T any](seq Seq[T], f func(T, T) int) monads.Option[T] {
+	out := monads.None[T]()
+	for v := range seq.Seq {
+		if !out.Valid || f(v, out.V) > 0 {
+			out = monads.Some(v)
+		}
+	}
+	return out
+}
+
+// MinBy returns the minimum element according to the comparison function.
+// If there are multiple minimums the first is returned.
+func MinBy[T any](seq Seq[T], f func(T, T) int) monads.Option[T] {
+	out := monads.None[T]()
+	for v := range seq.Seq {
+		if !out.Valid || f(v, out.V) < 0 {
+			out = monads.Some(v)
+		}
+	}
+	return out
+}
+
+// MaxOpt returns the maximum element of a sequence
+func MaxOpt[T cmp.Ordered](seq Seq[T]) monads.Option[T] {
+	return MaxBy(seq, cmp.Compare[T])
+}
+
+// MinOpt returns the minimum element of a sequence
+func MinOpt[T cmp.Ordered](seq Seq[T]) monads.Option[T] {
+	return MinBy(seq, cmp.Compare[T])
+}
+
+// AverageOpt is like [Average] but does not divide by zero
+func AverageOpt[T Numeric](seq Seq[T]) monads.Option[T] {
+	count := 0
+	var sum T
+	for v := range seq.Seq {
+		count++
+		sum += v
+	}
+	if count == 0 {
+		return monads.None[T]()
+	}
+	return monads.Some(sum / T(count))
+}
@@ -2,6 +2,7 @@ package reducers
 
 import (
 	"cmp"
+	"errors"
 	"slices"
 
 	. "github.com/rushsteve1/fp"
@@ -49,6 +50,21 @@ func CollectKV[K comparable, V any](seq Seq[KeyValue[K, V]]) map[K]V {
 	return out
 }
 
+// CollectErrs is like [Collect] for sequences of [monads.Result].
+// The ok values are collected and all errors are joined using [errors.Join]
+func CollectErrs[T any](seq Seq[monads.Result[T]]) monads.Result[[]T] {
+	var out []T
+	var errs []error
+	for r := range seq.Seq {
+		if r.Err != nil {
+			errs = append(errs, r.Err)
+			continue
+		}
+		out = append(out, r.V)
+	}
+	return monads.Wrap(out, errors.Join(errs...))
+}
+
 // Reduce consumes a sequence returning a final accumulator value
 func Reduce[T, Acc any](seq Seq[T], a Acc, f Reduction[T, Acc]) Acc {
 	for v := range seq.Seq {
@@ -57,22 +73,41 @@ func Reduce[T, Acc any](seq Seq[T], a Acc, f Reduction[T, Acc]) Acc {
 	return a
 }
 
-// First returns the first element of a sequence
-func First[T any](seq Seq[T]) (out T) {
-	c := Collect(seq)
-	if len(c) > 0 {
-		return c[0]
+// ReductionWhile is a [Reduction] that can also signal it is done by
+// returning false, like Clojure's reduced
+type ReductionWhile[T, Acc any] = func(T, Acc) (Acc, bool)
+
+// ReduceWhile is like [Reduce] but stops consuming the sequence as soon as the
+// reduction returns false, so it can be used with infinite sequences.
+// The accumulator returned along with false is the final value.
+func ReduceWhile[T, Acc any](seq Seq[T], a Acc, f ReductionWhile[T, Acc]) Acc {
+	for v := range seq.Seq {
+		var ok bool
+		a, ok = f(v, a)
+		if !ok {
+			break
+		}
 	}
-	return out
+	return a
 }
 
-// Last returns the last element of a sequence, which may not exist
-func Last[T any](seq Seq[T]) (out T) {
-	c := Collect(seq)
-	if len(c) > 0 {
-		return c[len(c)-1]
-	}
-	return out
+// FoldUntil is like [Reduce] but stops as soon as the accumulator passes the
+// predicate
+func FoldUntil[T, Acc any](seq Seq[T], a Acc, f Reduction[T, Acc], done fun.Predicate[Acc]) Acc {
+	return ReduceWhile(seq, a, func(t T, acc Acc) (Acc, bool) {
+		acc = f(t, acc)
+		return acc, !done(acc)
+	})
+}
+
+// First returns the first element of a sequence, or the zero value
+func First[T any](seq Seq[T]) T {
+	return FirstOpt(seq).V
+}
+
+// Last returns the last element of a sequence, or the zero value
+func Last[T any](seq Seq[T]) T {
+	return LastOpt(seq).V
 }
 
 // Index returns the element at the given index, if it exists
@@ -82,7 +117,7 @@ func Index[T any](seq Seq[T], i int) monads.Option[T] {
 		if ind == i {
 			return monads.Some(v)
 		}
-		i++
+		ind++
 	}
 	return monads.None[T]()
 }
@@ -111,15 +146,16 @@ func Min[T cmp.Ordered](seq Seq[T]) (out T) {
 	return out
 }
 
-// Median returns the median value of the sequence
-func Median[T cmp.Ordered](seq Seq[T]) T {
-	var hi T
-	var lo T
-	for v := range seq.Seq {
-		hi = max(hi, v, lo)
-		lo = max(lo, v, hi)
+// Median returns the median value of the sequence.
+// For an even number of elements the lower of the middle two is returned.
+// See [stats.Median] for an interpolating version.
+func Median[T cmp.Ordered](seq Seq[T]) (out T) {
+	c := Collect(seq)
+	if len(c) == 0 {
+		return out
 	}
-	return lo
+	slices.Sort(c)
+	return c[(len(c)-1)/2]
 }
 
 // Frequency returns the frequency of each element in the sequence
@@ -131,6 +167,29 @@ func Frequency[T cmp.Ordered](seq Seq[T]) map[T]int {
 	return out
 }
 
+// GroupBy collects the elements of the sequence into slices by the key
+// returned by f
+func GroupBy[T any, K comparable](seq Seq[T], f func(T) K) map[K][]T {
+	return GroupReduce(seq, f, nil, func(t T, acc []T) []T {
+		return append(acc, t)
+	})
+}
+
+// GroupReduce is like [Reduce] but keeps a separate accumulator for each key
+// returned by f, each starting from a
+func GroupReduce[T any, K comparable, Acc any](seq Seq[T], f func(T) K, a Acc, r Reduction[T, Acc]) map[K]Acc {
+	out := make(map[K]Acc)
+	for v := range seq.Seq {
+		k := f(v)
+		acc, ok := out[k]
+		if !ok {
+			acc = a
+		}
+		out[k] = r(v, acc)
+	}
+	return out
+}
+
 // Average returns the average of a numeric sequence
 func Average[T Numeric](seq Seq[T]) T {
 	count := 0
@@ -142,6 +201,20 @@ func Average[T Numeric](seq Seq[T]) T {
 	return sum / T(count)
 }
 
+// Partition splits the sequence into the elements that pass the predicate
+// and those that do not, consuming it only once.
+// See [transducers.Split] for a lazy version.
+func Partition[T any](seq Seq[T], f fun.Predicate[T]) (yes []T, no []T) {
+	for v := range seq.Seq {
+		if f(v) {
+			yes = append(yes, v)
+		} else {
+			no = append(no, v)
+		}
+	}
+	return yes, no
+}
+
 // Any returns true if any value in the sequence passes the predicate.
 // Short-circuits on the first passing value
 func Any[T any](seq Seq[T], f fun.Predicate[T]) bool {
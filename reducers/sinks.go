@@ -0,0 +1,163 @@
+package reducers
+
+import (
+	"container/heap"
+	"io"
+	"slices"
+	"strings"
+
+	. "github.com/rushsteve1/fp"
+	"github.com/rushsteve1/fp/monads"
+)
+
+// Sink is anything that values can be added to.
+// Any type implementing Sink can be collected into using [Into]
+type Sink[T any] interface {
+	Add(T)
+}
+
+// SinkFunc uses the same trick as [fp.SeqFunc] to turn a function into a [Sink]
+type SinkFunc[T any] func(T)
+
+func (sf SinkFunc[T]) Add(t T) {
+	sf(t)
+}
+
+// Into adds every element of the sequence to the sink, then returns it
+func Into[T any, S Sink[T]](seq Seq[T], s S) S {
+	for v := range seq.Seq {
+		s.Add(v)
+	}
+	return s
+}
+
+// Set is a set of comparable values built on a map
+type Set[T comparable] map[T]struct{}
+
+func (s Set[T]) Add(t T) {
+	s[t] = struct{}{}
+}
+
+// Has returns true if the value is in the set
+func (s Set[T]) Has(t T) bool {
+	_, ok := s[t]
+	return ok
+}
+
+// IntoSet collects the unique elements of the sequence into a [Set]
+func IntoSet[T comparable](seq Seq[T]) Set[T] {
+	return Into(seq, make(Set[T]))
+}
+
+// IntoChan sends every element of the sequence on the channel, then closes it.
+// Returns the number of elements sent.
+func IntoChan[T any](seq Seq[T], c chan<- T) (n int) {
+	defer close(c)
+	for v := range seq.Seq {
+		c <- v
+		n++
+	}
+	return n
+}
+
+// IntoWriter writes the encoding of every element to the writer,
+// stopping at the first error.
+// See [transducers.Write] for a non-terminal version.
+func IntoWriter[T any](seq Seq[T], w io.Writer, encode func(T) []byte) error {
+	for v := range seq.Seq {
+		if _, err := w.Write(encode(v)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Join concatenates the strings in the sequence with sep between them
+func Join[T ~string](seq Seq[T], sep string) string {
+	var sb strings.Builder
+	first := true
+	for v := range seq.Seq {
+		if !first {
+			sb.WriteString(sep)
+		}
+		first = false
+		sb.WriteString(string(v))
+	}
+	return sb.String()
+}
+
+// IntoSorted collects the sequence into a slice sorted by the comparison
+func IntoSorted[T any](seq Seq[T], cmp func(T, T) int) []T {
+	out := Collect(seq)
+	slices.SortFunc(out, cmp)
+	return out
+}
+
+// Heap is a priority queue ordered by its comparison function,
+// with the smallest element on top. It wraps [container/heap].
+type Heap[T any] struct {
+	h *heapSlice[T]
+}
+
+// heapSlice implements [heap.Interface]
+type heapSlice[T any] struct {
+	items []T
+	cmp   func(T, T) int
+}
+
+func (h heapSlice[T]) Len() int           { return len(h.items) }
+func (h heapSlice[T]) Less(i, j int) bool { return h.cmp(h.items[i], h.items[j]) < 0 }
+func (h heapSlice[T]) Swap(i, j int)      { h.items[i], h.items[j] = h.items[j], h.items[i] }
+func (h *heapSlice[T]) Push(x any)        { h.items = append(h.items, x.(T)) }
+
+func (h *heapSlice[T]) Pop() any {
+	n := len(h.items) - 1
+	out := h.items[n]
+	h.items = h.items[:n]
+	return out
+}
+
+// NewHeap creates an empty [Heap]
+func NewHeap[T any](cmp func(T, T) int) Heap[T] {
+	return Heap[T]{h: &heapSlice[T]{cmp: cmp}}
+}
+
+// Add implements [Sink] by pushing onto the heap
+func (h Heap[T]) Add(t T) {
+	heap.Push(h.h, t)
+}
+
+// Len returns the number of elements in the heap
+func (h Heap[T]) Len() int {
+	return h.h.Len()
+}
+
+// Peek returns the smallest element without removing it
+func (h Heap[T]) Peek() monads.Option[T] {
+	if h.Len() == 0 {
+		return monads.None[T]()
+	}
+	return monads.Some(h.h.items[0])
+}
+
+// Pop removes and returns the smallest element
+func (h Heap[T]) Pop() monads.Option[T] {
+	if h.Len() == 0 {
+		return monads.None[T]()
+	}
+	return monads.Some(heap.Pop(h.h).(T))
+}
+
+// Seq pops every element in order, emptying the heap
+func (h Heap[T]) Seq(yield func(T) bool) {
+	for h.Len() > 0 {
+		if !yield(heap.Pop(h.h).(T)) {
+			return
+		}
+	}
+}
+
+// IntoHeap collects the sequence into a [Heap]
+func IntoHeap[T any](seq Seq[T], cmp func(T, T) int) Heap[T] {
+	return Into(seq, NewHeap(cmp))
+}
@@ -0,0 +1,126 @@
+package reducers
+
+import (
+	. "github.com/rushsteve1/fp"
+)
+
+// Tee collectors combine multiple collectors into one that only consumes the
+// sequence once. This is like [fun.Multi2] but for collectors, and so works
+// with infinite and one-shot sequences.
+//
+// Each collector is run as a coroutine using [Pull] and is fed the elements
+// one at a time. The sequence stops early once every collector has stopped.
+
+// Tuple2 holds the results of [Tee2]
+type Tuple2[A, B any] struct {
+	A A
+	B B
+}
+
+// Tuple3 holds the results of [Tee3]
+type Tuple3[A, B, C any] struct {
+	A A
+	B B
+	C C
+}
+
+// Tuple4 holds the results of [Tee4]
+type Tuple4[A, B, C, D any] struct {
+	A A
+	B B
+	C C
+	D D
+}
+
+// tee runs every function as a coroutine, feeding each the same elements
+func tee[T any](seq Seq[T], fs ...func(Seq[T])) {
+	var cur T
+	done := false
+
+	nexts := make([]func() (struct{}, bool), 0, len(fs))
+	for _, f := range fs {
+		next, stop := Pull(SeqFunc[struct{}](func(suspend func(struct{}) bool) {
+			f(SeqFunc[T](func(yield func(T) bool) {
+				// Wait for the next element to be set before yielding it
+				for suspend(struct{}{}) && !done {
+					if !yield(cur) {
+						return
+					}
+				}
+			}))
+		}))
+		defer stop()
+		// Run until the collector asks for its first element
+		if _, ok := next(); ok {
+			nexts = append(nexts, next)
+		}
+	}
+
+	if len(nexts) > 0 {
+		seq.Seq(func(t T) bool {
+			cur = t
+			live := nexts[:0]
+			for _, next := range nexts {
+				if _, ok := next(); ok {
+					live = append(live, next)
+				}
+			}
+			nexts = live
+			return len(nexts) > 0
+		})
+	}
+
+	// Let the remaining collectors know the sequence has ended
+	done = true
+	for _, next := range nexts {
+		next()
+	}
+}
+
+// Tee combines any number of collectors with the same result type
+func Tee[T, Acc any](cs ...Collector[T, Acc]) Collector[T, []Acc] {
+	return func(seq Seq[T]) []Acc {
+		out := make([]Acc, len(cs))
+		fs := make([]func(Seq[T]), 0, len(cs))
+		for i, c := range cs {
+			fs = append(fs, func(s Seq[T]) { out[i] = c(s) })
+		}
+		tee(seq, fs...)
+		return out
+	}
+}
+
+// Generics up to 4, like [fun.Multi4]
+
+func Tee2[T, A, B any](a Collector[T, A], b Collector[T, B]) Collector[T, Tuple2[A, B]] {
+	return func(seq Seq[T]) (out Tuple2[A, B]) {
+		tee(seq,
+			func(s Seq[T]) { out.A = a(s) },
+			func(s Seq[T]) { out.B = b(s) },
+		)
+		return out
+	}
+}
+
+func Tee3[T, A, B, C any](a Collector[T, A], b Collector[T, B], c Collector[T, C]) Collector[T, Tuple3[A, B, C]] {
+	return func(seq Seq[T]) (out Tuple3[A, B, C]) {
+		tee(seq,
+			func(s Seq[T]) { out.A = a(s) },
+			func(s Seq[T]) { out.B = b(s) },
+			func(s Seq[T]) { out.C = c(s) },
+		)
+		return out
+	}
+}
+
+func Tee4[T, A, B, C, D any](a Collector[T, A], b Collector[T, B], c Collector[T, C], d Collector[T, D]) Collector[T, Tuple4[A, B, C, D]] {
+	return func(seq Seq[T]) (out Tuple4[A, B, C, D]) {
+		tee(seq,
+			func(s Seq[T]) { out.A = a(s) },
+			func(s Seq[T]) { out.B = b(s) },
+			func(s Seq[T]) { out.C = c(s) },
+			func(s Seq[T]) { out.D = d(s) },
+		)
+		return out
+	}
+}
@@ -0,0 +1,70 @@
+package sketches
+
+import (
+	"math"
+
+	. "github.com/rushsteve1/fp"
+	"github.com/rushsteve1/fp/reducers"
+)
+
+// Bloom is a Bloom filter, a set that can have false positives but never
+// false negatives
+type Bloom[T comparable] struct {
+	m    uint64
+	k    int
+	bits []uint64
+}
+
+// NewBloom creates an empty [Bloom] filter sized to hold n elements with a
+// false positive rate of p.
+// The rate is clamped to be strictly between 0 and 1.
+func NewBloom[T comparable](n int, p float64) *Bloom[T] {
+	fn := float64(max(n, 1))
+	p = Clamp(p, math.SmallestNonzeroFloat64, math.Nextafter(1, 0))
+	m := max(1, uint64(math.Ceil(-fn*math.Log(p)/(math.Ln2*math.Ln2))))
+	k := max(1, int(math.Round(float64(m)/fn*math.Ln2)))
+	return &Bloom[T]{
+		m:    m,
+		k:    k,
+		bits: make([]uint64, (m+63)/64),
+	}
+}
+
+// Add inserts a value, returning the same filter
+func (b *Bloom[T]) Add(v T) *Bloom[T] {
+	for i := range indexes(Hash(v), b.k, b.m) {
+		b.bits[i/64] |= 1 << (i % 64)
+	}
+	return b
+}
+
+// Has returns true if the value may have been added
+func (b *Bloom[T]) Has(v T) bool {
+	for i := range indexes(Hash(v), b.k, b.m) {
+		if b.bits[i/64]&(1<<(i%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Merge combines o into this filter, they must have the same parameters
+func (b *Bloom[T]) Merge(o *Bloom[T]) *Bloom[T] {
+	if b.m != o.m || b.k != o.k {
+		panic("Bloom merge with different dimensions")
+	}
+	for i, w := range o.bits {
+		b.bits[i] |= w
+	}
+	return b
+}
+
+// AddBloom is the [reducers.Reduction] for [Bloom]
+func AddBloom[T comparable](v T, b *Bloom[T]) *Bloom[T] {
+	return b.Add(v)
+}
+
+// BloomOf collects a sequence into a new [Bloom] filter
+func BloomOf[T comparable](seq Seq[T], n int, p float64) *Bloom[T] {
+	return reducers.Reduce(seq, NewBloom[T](n, p), AddBloom[T])
+}
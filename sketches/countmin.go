@@ -0,0 +1,137 @@
+package sketches
+
+import (
+	"cmp"
+	"maps"
+	"math"
+	"slices"
+
+	. "github.com/rushsteve1/fp"
+	"github.com/rushsteve1/fp/reducers"
+)
+
+// CountMin estimates how often each element occurs in a sequence.
+// Estimates are never too low, and are too high by at most epsilon times the
+// total count with a probability of 1 - delta.
+//
+// It also tracks the k elements with the highest estimates, which makes it a
+// low memory alternative to [reducers.Frequency] for finding heavy hitters.
+type CountMin[T comparable] struct {
+	width  uint64
+	depth  int
+	counts []uint64
+	total  uint64
+	k      int
+	top    map[T]uint64
+}
+
+// NewCountMin creates an empty [CountMin] that tracks the top k elements.
+// Both epsilon and delta are clamped to be strictly between 0 and 1.
+func NewCountMin[T comparable](epsilon, delta float64, k int) *CountMin[T] {
+	epsilon = Clamp(epsilon, math.SmallestNonzeroFloat64, math.Nextafter(1, 0))
+	delta = Clamp(delta, math.SmallestNonzeroFloat64, math.Nextafter(1, 0))
+	// Capped so that a tiny epsilon can't overflow the conversion
+	width := uint64(min(math.Ceil(math.E/epsilon), math.MaxUint32))
+	depth := int(math.Ceil(-math.Log(delta)))
+	return &CountMin[T]{
+		width:  width,
+		depth:  depth,
+		counts: make([]uint64, width*uint64(depth)),
+		k:      k,
+		top:    make(map[T]uint64, k+1),
+	}
+}
+
+// Add observes a value, returning the same sketch
+func (c *CountMin[T]) Add(v T) *CountMin[T] {
+	return c.AddN(v, 1)
+}
+
+// AddN observes a value n times
+func (c *CountMin[T]) AddN(v T, n uint64) *CountMin[T] {
+	row := uint64(0)
+	for i := range indexes(Hash(v), c.depth, c.width) {
+		c.counts[row+i] += n
+		row += c.width
+	}
+	c.total += n
+	c.track(v)
+	return c
+}
+
+// Count returns the estimated number of times v was observed
+func (c *CountMin[T]) Count(v T) uint64 {
+	out := uint64(math.MaxUint64)
+	row := uint64(0)
+	for i := range indexes(Hash(v), c.depth, c.width) {
+		out = min(out, c.counts[row+i])
+		row += c.width
+	}
+	return out
+}
+
+// Total returns the total number of values observed
+func (c *CountMin[T]) Total() uint64 {
+	return c.total
+}
+
+// track updates the top k with the current estimate of v.
+// This is a linear scan, so k is expected to be small.
+func (c *CountMin[T]) track(v T) {
+	if c.k <= 0 {
+		return
+	}
+	c.top[v] = c.Count(v)
+	if len(c.top) <= c.k {
+		return
+	}
+	var low T
+	lowest := uint64(math.MaxUint64)
+	for t, n := range c.top {
+		if n < lowest {
+			low, lowest = t, n
+		}
+	}
+	delete(c.top, low)
+}
+
+// Top returns the tracked elements, highest estimate first
+func (c *CountMin[T]) Top() []KeyValue[T, uint64] {
+	out := make([]KeyValue[T, uint64], 0, len(c.top))
+	for t, n := range c.top {
+		out = append(out, KeyValue[T, uint64]{Key: t, Value: n})
+	}
+	slices.SortFunc(out, func(a, b KeyValue[T, uint64]) int {
+		return cmp.Compare(b.Value, a.Value)
+	})
+	return out
+}
+
+// Merge combines o into this sketch, they must have the same parameters
+func (c *CountMin[T]) Merge(o *CountMin[T]) *CountMin[T] {
+	if c.width != o.width || c.depth != o.depth {
+		panic("CountMin merge with different dimensions")
+	}
+	for i, n := range o.counts {
+		c.counts[i] += n
+	}
+	c.total += o.total
+	for _, t := range slices.Collect(maps.Keys(o.top)) {
+		c.track(t)
+	}
+	// Estimates of the existing elements may have also changed
+	for t := range c.top {
+		c.top[t] = c.Count(t)
+	}
+	return c
+}
+
+// AddCountMin is the [reducers.Reduction] for [CountMin]
+func AddCountMin[T comparable](v T, c *CountMin[T]) *CountMin[T] {
+	return c.Add(v)
+}
+
+// CountMinOf collects a sequence into a new [CountMin]
+func CountMinOf[T comparable](seq Seq[T], epsilon, delta float64, k int) *CountMin[T] {
+	return reducers.Reduce(seq, NewCountMin[T](epsilon, delta, k), AddCountMin[T])
+}
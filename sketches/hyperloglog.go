@@ -0,0 +1,84 @@
+package sketches
+
+import (
+	"math"
+	"math/bits"
+
+	. "github.com/rushsteve1/fp"
+	"github.com/rushsteve1/fp/reducers"
+)
+
+// HyperLogLog estimates the number of distinct elements in a sequence.
+// It uses 2^precision bytes of memory, with a standard error of roughly
+// 1.04 / sqrt(2^precision).
+type HyperLogLog[T comparable] struct {
+	p    uint8
+	regs []uint8
+}
+
+// NewHyperLogLog creates an empty [HyperLogLog], precision is clamped to 4-16
+func NewHyperLogLog[T comparable](precision uint8) *HyperLogLog[T] {
+	p := Clamp(precision, 4, 16)
+	return &HyperLogLog[T]{
+		p:    p,
+		regs: make([]uint8, 1<<p),
+	}
+}
+
+// Add observes a value, returning the same sketch
+func (h *HyperLogLog[T]) Add(v T) *HyperLogLog[T] {
+	x := Hash(v)
+	i := x >> (64 - h.p)
+	// Guard bit so the rank never overflows
+	w := x<<h.p | 1<<(h.p-1)
+	h.regs[i] = max(h.regs[i], uint8(bits.LeadingZeros64(w)+1))
+	return h
+}
+
+// Count returns the estimated number of distinct values
+func (h *HyperLogLog[T]) Count() uint64 {
+	m := float64(len(h.regs))
+	sum := 0.0
+	zeros := 0
+	for _, r := range h.regs {
+		sum += math.Ldexp(1, -int(r))
+		if r == 0 {
+			zeros++
+		}
+	}
+
+	alpha := 0.7213 / (1 + 1.079/m)
+	est := alpha * m * m / sum
+	// Linear counting is more accurate for small cardinalities
+	if est <= 2.5*m && zeros > 0 {
+		est = m * math.Log(m/float64(zeros))
+	}
+	return uint64(est + 0.5)
+}
+
+// Merge combines o into this sketch, they must have the same precision
+func (h *HyperLogLog[T]) Merge(o *HyperLogLog[T]) *HyperLogLog[T] {
+	if h.p != o.p {
+		panic("HyperLogLog merge with different precision")
+	}
+	for i, r := range o.regs {
+		h.regs[i] = max(h.regs[i], r)
+	}
+	return h
+}
+
+// AddHyperLogLog is the [reducers.Reduction] for [HyperLogLog]
+func AddHyperLogLog[T comparable](v T, h *HyperLogLog[T]) *HyperLogLog[T] {
+	return h.Add(v)
+}
+
+// HyperLogLogOf collects a sequence into a new [HyperLogLog]
+func HyperLogLogOf[T comparable](seq Seq[T], precision uint8) *HyperLogLog[T] {
+	return reducers.Reduce(seq, NewHyperLogLog[T](precision), AddHyperLogLog[T])
+}
+
+// Cardinality is a [reducers.Collector] for the approximate number of distinct
+// elements, a low memory alternative to counting the keys of [reducers.Frequency]
+func Cardinality[T comparable](seq Seq[T]) uint64 {
+	return HyperLogLogOf(seq, 14).Count()
+}
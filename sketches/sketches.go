@@ -0,0 +1,111 @@
+// This package implements probabilistic sketches, which summarize a sequence
+// in a fixed amount of memory at the cost of some accuracy.
+//
+// Every sketch can be built using [reducers.Reduce] with its Add method,
+// or collected directly from a sequence with the matching Of function.
+// Sketches with the same parameters can be merged, so partial results
+// computed separately can be combined.
+
+package sketches
+
+import (
+	"encoding/binary"
+	"hash"
+	"hash/fnv"
+	"math"
+	"reflect"
+)
+
+// Hash returns a deterministic 64-bit hash of any comparable value.
+// Strings and numbers are hashed directly, everything else is hashed field by
+// field using reflection, so values that are == always hash the same.
+// Floats are hashed by value, so -0 and +0 hash the same.
+//
+// It is deterministic so that sketches built separately can be merged.
+func Hash[T comparable](v T) uint64 {
+	h := fnv.New64a()
+	switch x := any(v).(type) {
+	case string:
+		h.Write([]byte(x))
+	case int:
+		h.Write(binary.LittleEndian.AppendUint64(nil, uint64(x)))
+	case int64:
+		h.Write(binary.LittleEndian.AppendUint64(nil, uint64(x)))
+	case uint64:
+		h.Write(binary.LittleEndian.AppendUint64(nil, x))
+	case float64:
+		// Adding zero turns -0 into +0
+		h.Write(binary.LittleEndian.AppendUint64(nil, math.Float64bits(x+0)))
+	case float32:
+		h.Write(binary.LittleEndian.AppendUint32(nil, math.Float32bits(x+0)))
+	default:
+		hashValue(h, reflect.ValueOf(x))
+	}
+	return mix(h.Sum64())
+}
+
+// hashValue writes every part of v that == compares
+func hashValue(h hash.Hash64, v reflect.Value) {
+	le := binary.LittleEndian
+	switch v.Kind() {
+	case reflect.Invalid:
+		h.Write([]byte{0})
+	case reflect.Bool:
+		if v.Bool() {
+			h.Write([]byte{1})
+		} else {
+			h.Write([]byte{0})
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		h.Write(le.AppendUint64(nil, uint64(v.Int())))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		h.Write(le.AppendUint64(nil, v.Uint()))
+	case reflect.Float32, reflect.Float64:
+		h.Write(le.AppendUint64(nil, math.Float64bits(v.Float()+0)))
+	case reflect.Complex64, reflect.Complex128:
+		c := v.Complex()
+		h.Write(le.AppendUint64(nil, math.Float64bits(real(c)+0)))
+		h.Write(le.AppendUint64(nil, math.Float64bits(imag(c)+0)))
+	case reflect.String:
+		// The length keeps adjacent strings from running together
+		h.Write(le.AppendUint64(nil, uint64(v.Len())))
+		h.Write([]byte(v.String()))
+	case reflect.Array:
+		for i := range v.Len() {
+			hashValue(h, v.Index(i))
+		}
+	case reflect.Struct:
+		for i := range v.NumField() {
+			hashValue(h, v.Field(i))
+		}
+	case reflect.Interface:
+		if !v.IsNil() {
+			h.Write([]byte(v.Elem().Type().String()))
+		}
+		hashValue(h, v.Elem())
+	case reflect.Pointer, reflect.Chan, reflect.UnsafePointer:
+		h.Write(le.AppendUint64(nil, uint64(v.Pointer())))
+	}
+}
+
+// mix is the splitmix64 finalizer, which spreads FNV's output across all bits
+func mix(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
+
+// indexes uses double hashing to derive k indexes below m from one hash
+func indexes(h uint64, k int, m uint64) func(yield func(uint64) bool) {
+	h1, h2 := h&math.MaxUint32, h>>32|1
+	return func(yield func(uint64) bool) {
+		for i := range uint64(k) {
+			if !yield((h1 + i*h2) % m) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package sketches_test
+
+import (
+	"math"
+	"strconv"
+	"testing"
+
+	. "github.com/rushsteve1/fp"
+	"github.com/rushsteve1/fp/generators"
+	. "github.com/rushsteve1/fp/sketches"
+	. "github.com/rushsteve1/fp/transducers"
+)
+
+func TestHyperLogLog(t *testing.T) {
+	seq := Map(Take(generators.Integers(), 100000), func(x int) int { return x % 20000 })
+	n := Cardinality(seq)
+	Assert(t, math.Abs(float64(n)-20000) < 20000*0.03)
+
+	small := HyperLogLogOf(Take(generators.Integers(), 10), 12)
+	AssertEq(t, small.Count(), 10)
+
+	a := HyperLogLogOf(Take(generators.Integers(), 5000), 12)
+	b := HyperLogLogOf(Drop(Take(generators.Integers(), 10000), 5000), 12)
+	n = a.Merge(b).Count()
+	Assert(t, math.Abs(float64(n)-10000) < 10000*0.05)
+}
+
+func TestCountMin(t *testing.T) {
+	seq := Map(Take(generators.Integers(), 10000), func(x int) string {
+		if x%10 == 0 {
+			return "hot"
+		}
+		return strconv.Itoa(x)
+	})
+	c := CountMinOf(seq, 0.001, 0.01, 3)
+	AssertEq(t, c.Total(), 10000)
+	hot := c.Count("hot")
+	Assert(t, hot >= 1000 && hot < 1020)
+	AssertEq(t, c.Top()[0].Key, "hot")
+
+	c.Merge(CountMinOf(generators.Once("hot"), 0.001, 0.01, 3))
+	AssertEq(t, c.Count("hot"), hot+1)
+
+	for _, p := range [][2]float64{{0.1, 1}, {0.1, 0}, {0.1, -1}, {2, 0.5}} {
+		c := CountMinOf(Take(generators.Integers(), 100), p[0], p[1], 1)
+		Assert(t, c.Count(1) >= 1 && c.Count(1) <= 100)
+	}
+}
+
+func TestBloom(t *testing.T) {
+	b := BloomOf(Take(generators.Integers(), 1000), 1000, 0.01)
+	for i := range 1000 {
+		Assert(t, b.Has(i))
+	}
+
+	falses := 0
+	for i := 1000; i < 11000; i++ {
+		if b.Has(i) {
+			falses++
+		}
+	}
+	Assert(t, falses < 200)
+
+	o := BloomOf(generators.Once(-1), 1000, 0.01)
+	Assert(t, b.Merge(o).Has(-1))
+}
+
+func TestBloomRate(t *testing.T) {
+	for _, p := range []float64{0, 1, -1, 2} {
+		b := BloomOf(Take(generators.Integers(), 100), 100, p)
+		for i := range 100 {
+			Assert(t, b.Has(i))
+		}
+	}
+}
+
+func TestHashZero(t *testing.T) {
+	negZero := math.Copysign(0, -1)
+	AssertEq(t, Hash(negZero), Hash(0.0))
+	AssertEq(t, Hash(float32(negZero)), Hash(float32(0)))
+	AssertEq(t, NewHyperLogLog[float64](10).Add(negZero).Add(0).Count(), 1)
+
+	type point struct {
+		X float64
+		Y any
+	}
+	AssertEq(t, Hash(point{negZero, 1}), Hash(point{0, 1}))
+	Assert(t, Hash(point{0, 1}) != Hash(point{0, int64(1)}))
+	Assert(t, Hash([2]string{"ab", "c"}) != Hash([2]string{"a", "bc"}))
+	b := NewBloom[point](10, 0.01).Add(point{X: negZero})
+	Assert(t, b.Has(point{}))
+}
@@ -0,0 +1,155 @@
+package stats
+
+import (
+	"math"
+	"slices"
+	"sort"
+
+	. "github.com/rushsteve1/fp"
+	"github.com/rushsteve1/fp/reducers"
+	"github.com/rushsteve1/fp/transducers"
+)
+
+// GK is a Greenwald-Khanna quantile sketch.
+// It answers quantile queries over unbounded data within a rank error of
+// Epsilon * Count, while only keeping a small summary of the values seen.
+//
+// Sketches are updated in place, so the values yielded by [transducers.Scan]
+// are all the same sketch. Use [RunningSketch] to get a separate sketch for
+// every element.
+type GK struct {
+	Epsilon float64
+	n       int
+	tuples  []gkTuple
+}
+
+type gkTuple struct {
+	v float64
+	// g is the difference in minimum rank from the previous tuple
+	g int
+	// delta is the difference between the minimum and maximum rank
+	delta int
+}
+
+// NewGK creates a new empty [GK] sketch
+func NewGK(epsilon float64) *GK {
+	return &GK{Epsilon: epsilon}
+}
+
+// Clone returns a copy of the sketch that can be added to separately
+func (s *GK) Clone() *GK {
+	c := *s
+	c.tuples = slices.Clone(s.tuples)
+	return &c
+}
+
+// Count returns the number of values that have been added
+func (s *GK) Count() int {
+	return s.n
+}
+
+// Add inserts x into the sketch, returning the same sketch
+func (s *GK) Add(x float64) *GK {
+	i := sort.Search(len(s.tuples), func(i int) bool {
+		return s.tuples[i].v >= x
+	})
+
+	delta := 0
+	if i > 0 && i < len(s.tuples) {
+		delta = int(math.Floor(2 * s.Epsilon * float64(s.n)))
+	}
+
+	s.tuples = append(s.tuples, gkTuple{})
+	copy(s.tuples[i+1:], s.tuples[i:])
+	s.tuples[i] = gkTuple{v: x, g: 1, delta: delta}
+	s.n++
+
+	if s.n%s.period() == 0 {
+		s.compress()
+	}
+	return s
+}
+
+func (s *GK) period() int {
+	return max(1, int(1/(2*s.Epsilon)))
+}
+
+// compress merges adjacent tuples while staying within the error bound
+func (s *GK) compress() {
+	limit := int(math.Floor(2 * s.Epsilon * float64(s.n)))
+	for i := len(s.tuples) - 2; i >= 1; i-- {
+		a, b := s.tuples[i], s.tuples[i+1]
+		if a.g+b.g+b.delta <= limit {
+			s.tuples[i+1].g += a.g
+			s.tuples = append(s.tuples[:i], s.tuples[i+1:]...)
+		}
+	}
+}
+
+// Quantile returns an approximate q quantile, or NaN if the sketch is empty
+func (s *GK) Quantile(q float64) float64 {
+	if len(s.tuples) == 0 {
+		return math.NaN()
+	}
+
+	rank := math.Ceil(Clamp(q, 0, 1) * float64(s.n))
+	bound := s.Epsilon * float64(s.n)
+	rmin := 0
+	for i, t := range s.tuples {
+		rmin += t.g
+		if float64(rmin+t.delta) > rank+bound {
+			if i == 0 {
+				return t.v
+			}
+			return s.tuples[i-1].v
+		}
+	}
+	return s.tuples[len(s.tuples)-1].v
+}
+
+// Merge adds all the values summarized by o into this sketch.
+// The error bound of the result is the larger of the two.
+func (s *GK) Merge(o *GK) *GK {
+	s.Epsilon = max(s.Epsilon, o.Epsilon)
+	merged := make([]gkTuple, 0, len(s.tuples)+len(o.tuples))
+	i, j := 0, 0
+	for i < len(s.tuples) || j < len(o.tuples) {
+		// Each tuple's maximum rank grows by the uncertainty of the next tuple
+		// from the other sketch, since any of its values may come before
+		if j >= len(o.tuples) || (i < len(s.tuples) && s.tuples[i].v <= o.tuples[j].v) {
+			t := s.tuples[i]
+			if j < len(o.tuples) {
+				t.delta += o.tuples[j].g + o.tuples[j].delta - 1
+			}
+			merged = append(merged, t)
+			i++
+		} else {
+			t := o.tuples[j]
+			if i < len(s.tuples) {
+				t.delta += s.tuples[i].g + s.tuples[i].delta - 1
+			}
+			merged = append(merged, t)
+			j++
+		}
+	}
+	s.tuples = merged
+	s.n += o.n
+	s.compress()
+	return s
+}
+
+// AddGK is the [reducers.Reduction] for [GK]
+func AddGK[T Numeric](x T, s *GK) *GK {
+	return s.Add(float64(x))
+}
+
+// Sketch collects a sequence into a new [GK] sketch
+func Sketch[T Numeric](seq Seq[T], epsilon float64) *GK {
+	return reducers.Reduce(seq, NewGK(epsilon), AddGK[T])
+}
+
+// RunningSketch yields the [GK] sketch of the sequence so far for every
+// element, each one is a separate copy
+func RunningSketch[T Numeric](seq Seq[T], epsilon float64) Seq[*GK] {
+	return transducers.Map(transducers.Scan(seq, NewGK(epsilon), AddGK[T]), (*GK).Clone)
+}
@@ -0,0 +1,101 @@
+package stats
+
+import (
+	"slices"
+	"sort"
+
+	. "github.com/rushsteve1/fp"
+	"github.com/rushsteve1/fp/reducers"
+	"github.com/rushsteve1/fp/transducers"
+)
+
+// Histogram counts values into buckets.
+// Counts[i] is the number of values less than or equal to Bounds[i] and
+// greater than the previous bound. The final count is for values greater
+// than every bound.
+//
+// Histograms share their counts when copied, so the values yielded by
+// [transducers.Scan] are all the same histogram. Use [RunningHistogram] to
+// get a separate histogram for every element.
+type Histogram struct {
+	Bounds []float64
+	Counts []int
+}
+
+// NewHistogram creates an empty [Histogram] with the given bucket bounds
+func NewHistogram(bounds []float64) Histogram {
+	b := slices.Clone(bounds)
+	slices.Sort(b)
+	return Histogram{
+		Bounds: b,
+		Counts: make([]int, len(b)+1),
+	}
+}
+
+// LinearBuckets returns count bounds, each width apart
+func LinearBuckets(start, width float64, count int) []float64 {
+	out := make([]float64, 0, count)
+	for i := range count {
+		out = append(out, start+width*float64(i))
+	}
+	return out
+}
+
+// ExponentialBuckets returns count bounds, each factor times the previous
+func ExponentialBuckets(start, factor float64, count int) []float64 {
+	out := make([]float64, 0, count)
+	for range count {
+		out = append(out, start)
+		start *= factor
+	}
+	return out
+}
+
+// Add counts x in the matching bucket
+func (h Histogram) Add(x float64) Histogram {
+	h.Counts[sort.SearchFloat64s(h.Bounds, x)]++
+	return h
+}
+
+// Merge adds the counts of o, which must have the same bounds
+func (h Histogram) Merge(o Histogram) Histogram {
+	if !slices.Equal(h.Bounds, o.Bounds) {
+		panic("Histogram merge with different bounds")
+	}
+	for i, c := range o.Counts {
+		h.Counts[i] += c
+	}
+	return h
+}
+
+// Clone returns a copy of the histogram that does not share its counts
+func (h Histogram) Clone() Histogram {
+	return Histogram{
+		Bounds: h.Bounds,
+		Counts: slices.Clone(h.Counts),
+	}
+}
+
+// Total returns the number of values counted
+func (h Histogram) Total() (n int) {
+	for _, c := range h.Counts {
+		n += c
+	}
+	return n
+}
+
+// AddHistogram is the [reducers.Reduction] for [Histogram]
+func AddHistogram[T Numeric](x T, h Histogram) Histogram {
+	return h.Add(float64(x))
+}
+
+// HistogramOf collects a sequence into a new [Histogram]
+func HistogramOf[T Numeric](seq Seq[T], bounds []float64) Histogram {
+	return reducers.Reduce(seq, NewHistogram(bounds), AddHistogram[T])
+}
+
+// RunningHistogram yields the [Histogram] of the sequence so far for every
+// element, each one is a separate copy
+func RunningHistogram[T Numeric](seq Seq[T], bounds []float64) Seq[Histogram] {
+	return transducers.Map(transducers.Scan(seq, NewHistogram(bounds), AddHistogram[T]), Histogram.Clone)
+}
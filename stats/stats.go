@@ -0,0 +1,149 @@
+// This package implements streaming statistics over [fp.Seq].
+//
+// Everything here is available as a [reducers.Reduction] so it can be used
+// with both [reducers.Reduce] and [transducers.Scan], as well as a
+// [reducers.Collector] that can be passed directly to [transducers.Transduce].
+// All calculations are done using float64 to avoid integer overflow.
+
+package stats
+
+import (
+	"math"
+	"slices"
+
+	. "github.com/rushsteve1/fp"
+	"github.com/rushsteve1/fp/reducers"
+	"github.com/rushsteve1/fp/transducers"
+)
+
+// Moments tracks the count, mean and variance of a sequence using
+// Welford's online algorithm, which is numerically stable
+type Moments struct {
+	Count int
+	Mean  float64
+	// M2 is the sum of the squared differences from the mean
+	M2 float64
+}
+
+// Add returns the new moments after observing x
+func (m Moments) Add(x float64) Moments {
+	m.Count++
+	d := x - m.Mean
+	m.Mean += d / float64(m.Count)
+	m.M2 += d * (x - m.Mean)
+	return m
+}
+
+// Merge combines two moments as if all their values had been observed by one
+func (m Moments) Merge(o Moments) Moments {
+	if m.Count == 0 {
+		return o
+	}
+	if o.Count == 0 {
+		return m
+	}
+	n := float64(m.Count + o.Count)
+	d := o.Mean - m.Mean
+	return Moments{
+		Count: m.Count + o.Count,
+		Mean:  m.Mean + d*float64(o.Count)/n,
+		M2:    m.M2 + o.M2 + d*d*float64(m.Count)*float64(o.Count)/n,
+	}
+}
+
+// Variance returns the population variance, or NaN if nothing was observed
+func (m Moments) Variance() float64 {
+	if m.Count == 0 {
+		return math.NaN()
+	}
+	return m.M2 / float64(m.Count)
+}
+
+// SampleVariance returns the sample variance using Bessel's correction
+func (m Moments) SampleVariance() float64 {
+	if m.Count < 2 {
+		return math.NaN()
+	}
+	return m.M2 / float64(m.Count-1)
+}
+
+// StdDev returns the population standard deviation
+func (m Moments) StdDev() float64 {
+	return math.Sqrt(m.Variance())
+}
+
+// Welford is the [reducers.Reduction] for [Moments]
+func Welford[T Numeric](x T, m Moments) Moments {
+	return m.Add(float64(x))
+}
+
+// Describe returns the [Moments] of a sequence
+func Describe[T Numeric](seq Seq[T]) Moments {
+	return reducers.Reduce(seq, Moments{}, Welford[T])
+}
+
+// Running yields the [Moments] of the sequence so far for every element
+func Running[T Numeric](seq Seq[T]) Seq[Moments] {
+	return transducers.Scan(seq, Moments{}, Welford[T])
+}
+
+// Mean returns the arithmetic mean of a sequence, or NaN if it is empty
+func Mean[T Numeric](seq Seq[T]) float64 {
+	m := Describe(seq)
+	if m.Count == 0 {
+		return math.NaN()
+	}
+	return m.Mean
+}
+
+// Variance returns the population variance of a sequence
+func Variance[T Numeric](seq Seq[T]) float64 {
+	return Describe(seq).Variance()
+}
+
+// StdDev returns the population standard deviation of a sequence
+func StdDev[T Numeric](seq Seq[T]) float64 {
+	return Describe(seq).StdDev()
+}
+
+// Quantiles returns the exact quantiles of a sequence, interpolating between
+// the closest elements. Each q must be between 0 and 1.
+//
+// This has to collect and sort the entire sequence, so it should only be used
+// on bounded data. See [GK] for an approximate alternative.
+func Quantiles[T Numeric](seq Seq[T], qs ...float64) []float64 {
+	xs := reducers.Collect(transducers.Map(seq, func(t T) float64 {
+		return float64(t)
+	}))
+	slices.Sort(xs)
+
+	out := make([]float64, 0, len(qs))
+	for _, q := range qs {
+		out = append(out, quantile(xs, q))
+	}
+	return out
+}
+
+// quantile expects xs to be sorted
+func quantile(xs []float64, q float64) float64 {
+	if len(xs) == 0 {
+		return math.NaN()
+	}
+	h := float64(len(xs)-1) * Clamp(q, 0, 1)
+	lo := math.Floor(h)
+	i := int(lo)
+	if i+1 >= len(xs) {
+		return xs[i]
+	}
+	return xs[i] + (h-lo)*(xs[i+1]-xs[i])
+}
+
+// Quantile is [Quantiles] for a single q
+func Quantile[T Numeric](seq Seq[T], q float64) float64 {
+	return Quantiles(seq, q)[0]
+}
+
+// Median returns the exact median of a sequence
+func Median[T Numeric](seq Seq[T]) float64 {
+	return Quantile(seq, 0.5)
+}
@@ -0,0 +1,107 @@
+package stats_test
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	. "github.com/rushsteve1/fp"
+	. "github.com/rushsteve1/fp/fun"
+	"github.com/rushsteve1/fp/generators"
+	"github.com/rushsteve1/fp/reducers"
+	. "github.com/rushsteve1/fp/stats"
+	. "github.com/rushsteve1/fp/transducers"
+)
+
+func near(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestMoments(t *testing.T) {
+	seq := SeqFunc[int](slices.Values([]int{2, 4, 4, 4, 5, 5, 7, 9}))
+	m := Describe(seq)
+	AssertEq(t, m.Count, 8)
+	Assert(t, near(m.Mean, 5))
+	Assert(t, near(m.StdDev(), 2))
+	Assert(t, near(StdDev(seq), 2))
+
+	a := Describe(Take(seq, 3))
+	b := Describe(Drop(seq, 3))
+	Assert(t, near(a.Merge(b).Variance(), 4))
+
+	Assert(t, math.IsNaN(Mean(generators.Empty[int]())))
+}
+
+func TestMeanOverflow(t *testing.T) {
+	seq := SeqFunc[int8](slices.Values([]int8{100, 100, 101}))
+	Assert(t, near(Mean(seq), 301.0/3))
+}
+
+func TestRunning(t *testing.T) {
+	means := Transduce(
+		generators.Integers(),
+		Chain3(
+			Curry2(Take[int], 4),
+			Running[int],
+			Curry2(Map, func(m Moments) float64 { return m.Mean }),
+		),
+		reducers.Collect,
+	)
+	AssertSliceEq(t, means, []float64{0, 0.5, 1, 1.5})
+}
+
+func TestQuantiles(t *testing.T) {
+	seq := SeqFunc[int](slices.Values([]int{5, 1, 4, 2, 3}))
+	AssertSliceEq(t, Quantiles(seq, 0, 0.25, 0.5, 1), []float64{1, 2, 3, 5})
+	Assert(t, near(Median(Take(seq, 4)), 3))
+	AssertEq(t, reducers.Median(seq), 3)
+}
+
+func TestGK(t *testing.T) {
+	eps := 0.01
+	s := Transduce(
+		generators.Integers(),
+		Chain2(
+			Curry2(Take[int], 10000),
+			Curry2(Map, func(x int) int { return (x * 7919) % 10000 }),
+		),
+		Curry2(Sketch[int], eps),
+	)
+	AssertEq(t, s.Count(), 10000)
+	for _, q := range []float64{0.1, 0.5, 0.9, 0.99} {
+		got := s.Quantile(q)
+		Assert(t, math.Abs(got-q*10000) <= eps*10000)
+	}
+
+	o := Sketch(Take(generators.Integers(), 10000), eps)
+	s.Merge(o)
+	AssertEq(t, s.Count(), 20000)
+	Assert(t, math.Abs(s.Quantile(0.5)-5000) <= eps*20000)
+}
+
+func TestHistogram(t *testing.T) {
+	h := HistogramOf(Take(generators.Integers(), 10), LinearBuckets(2, 3, 3))
+	AssertSliceEq(t, h.Bounds, []float64{2, 5, 8})
+	AssertSliceEq(t, h.Counts, []int{3, 3, 3, 1})
+	AssertEq(t, h.Total(), 10)
+
+	AssertSliceEq(t, ExponentialBuckets(1, 2, 4), []float64{1, 2, 4, 8})
+
+	h = h.Merge(HistogramOf(generators.Once(100), h.Bounds))
+	AssertEq(t, h.Counts[3], 2)
+
+	hs := reducers.Collect(RunningHistogram(Take(generators.Integers(), 3), []float64{10}))
+	AssertEq(t, len(hs), 3)
+	for i, h := range hs {
+		AssertEq(t, h.Total(), i+1)
+	}
+}
+
+func TestRunningSketch(t *testing.T) {
+	ss := reducers.Collect(RunningSketch(Take(generators.Integers(), 3), 0.01))
+	AssertEq(t, len(ss), 3)
+	for i, s := range ss {
+		AssertEq(t, s.Count(), i+1)
+		AssertEq(t, s.Quantile(1), float64(i))
+	}
+}
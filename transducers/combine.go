@@ -0,0 +1,101 @@
+package transducers
+
+import (
+	"sync"
+
+	. "github.com/rushsteve1/fp"
+)
+
+// Combinators take multiple sequences and combine them into one.
+// Every input is stopped when the resulting sequence stops.
+
+// ZipWith pairs up the elements of both sequences and yields the result of f.
+// It stops when either sequence ends.
+func ZipWith[T, U, V any](a Seq[T], b Seq[U], f func(T, U) V) Seq[V] {
+	return SeqFunc[V](func(yield func(V) bool) {
+		next, stop := Pull(b)
+		defer stop()
+		a.Seq(func(t T) bool {
+			u, ok := next()
+			if !ok {
+				return false
+			}
+			return yield(f(t, u))
+		})
+	})
+}
+
+// Zip pairs up the elements of both sequences into a [Seq2]
+func Zip[K comparable, V any](a Seq[K], b Seq[V]) Seq2[K, V] {
+	return Duet(ZipWith(a, b, func(k K, v V) KeyValue[K, V] {
+		return KeyValue[K, V]{Key: k, Value: v}
+	}))
+}
+
+// Interleave yields one element from each sequence in turn.
+// Sequences that end are skipped and the rest keep going.
+func Interleave[T any](seqs ...Seq[T]) Seq[T] {
+	return SeqFunc[T](func(yield func(T) bool) {
+		nexts := make([]func() (T, bool), 0, len(seqs))
+		for _, seq := range seqs {
+			next, stop := Pull(seq)
+			defer stop()
+			nexts = append(nexts, next)
+		}
+
+		for len(nexts) > 0 {
+			live := nexts[:0]
+			for _, next := range nexts {
+				t, ok := next()
+				if !ok {
+					continue
+				}
+				if !yield(t) {
+					return
+				}
+				live = append(live, next)
+			}
+			nexts = live
+		}
+	})
+}
+
+// Merge consumes every sequence concurrently, yielding elements as soon as
+// any of them produces one. It ends once all of the sequences have ended.
+//
+// Each sequence is consumed on its own goroutine, when the downstream stops
+// those goroutines exit the next time their sequence yields a value.
+func Merge[T any](seqs ...Seq[T]) Seq[T] {
+	return SeqFunc[T](func(yield func(T) bool) {
+		c := make(chan T)
+		done := make(chan struct{})
+		defer close(done)
+
+		var wg sync.WaitGroup
+		for _, seq := range seqs {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				seq.Seq(func(t T) bool {
+					select {
+					case c <- t:
+						return true
+					case <-done:
+						return false
+					}
+				})
+			}()
+		}
+
+		go func() {
+			wg.Wait()
+			close(c)
+		}()
+
+		for t := range c {
+			if !yield(t) {
+				return
+			}
+		}
+	})
+}
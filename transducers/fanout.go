@@ -0,0 +1,90 @@
+package transducers
+
+import (
+	"sync"
+
+	. "github.com/rushsteve1/fp"
+)
+
+// Fan-out transducers send one sequence to multiple consumers.
+
+// TeeBufSize is the number of elements each sequence returned by [Tee] can
+// fall behind the fastest one before it blocks
+var TeeBufSize = 16
+
+// Tee returns count sequences that each yield every element of the original
+// sequence, which is only consumed once.
+//
+// The returned sequences are meant to be consumed on separate goroutines.
+// Each has a buffer of [TeeBufSize] elements, once that is full the original
+// sequence blocks until it is consumed, so the slowest consumer sets the pace.
+// A sequence that is never consumed will eventually block all the others.
+//
+// Once a sequence stops it is skipped, and the original sequence is stopped
+// once all of them have.
+// A negative count is treated as 0.
+func Tee[T any](seq Seq[T], count int) []Seq[T] {
+	count = max(count, 0)
+	chans := make([]chan T, count)
+	dones := make([]chan struct{}, count)
+	for i := range count {
+		chans[i] = make(chan T, TeeBufSize)
+		dones[i] = make(chan struct{})
+	}
+
+	// The original sequence starts when the first returned sequence does
+	var start sync.Once
+	produce := func() {
+		defer func() {
+			for _, c := range chans {
+				close(c)
+			}
+		}()
+		stopped := make([]bool, count)
+		live := count
+		seq.Seq(func(t T) bool {
+			for i, c := range chans {
+				if stopped[i] {
+					continue
+				}
+				select {
+				case c <- t:
+				case <-dones[i]:
+					stopped[i] = true
+					live--
+				}
+			}
+			return live > 0
+		})
+	}
+
+	out := make([]Seq[T], 0, count)
+	for i := range count {
+		var once sync.Once
+		out = append(out, SeqFunc[T](func(yield func(T) bool) {
+			start.Do(func() { go produce() })
+			defer once.Do(func() { close(dones[i]) })
+			for t := range chans[i] {
+				if !yield(t) {
+					return
+				}
+			}
+		}))
+	}
+	return out
+}
+
+// Broadcast consumes the sequence once, passing it to every function using
+// [Tee]. Each function is run on its own goroutine and Broadcast returns once
+// all of them have.
+func Broadcast[T any](seq Seq[T], fs ...func(Seq[T])) {
+	var wg sync.WaitGroup
+	for i, s := range Tee(seq, len(fs)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			fs[i](s)
+		}()
+	}
+	wg.Wait()
+}
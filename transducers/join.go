@@ -0,0 +1,155 @@
+package transducers
+
+import (
+	"cmp"
+
+	. "github.com/rushsteve1/fp"
+	"github.com/rushsteve1/fp/monads"
+)
+
+// Join transducers correlate the elements of two sequences by a key,
+// yielding a [Seq2] of the key and the [Joined] elements.
+// The mode is optional and defaults to [InnerJoin].
+
+// JoinMode determines which unmatched elements are included in a join
+type JoinMode int
+
+const (
+	// InnerJoin only yields elements that match on both sides
+	InnerJoin JoinMode = iota
+	// LeftJoin also yields left elements that have no match
+	LeftJoin
+	// OuterJoin also yields left and right elements that have no match
+	OuterJoin
+)
+
+// Joined is a pair of elements from a join, either of which may be missing
+// in a [LeftJoin] or [OuterJoin]
+type Joined[L, R any] struct {
+	Left  monads.Option[L]
+	Right monads.Option[R]
+}
+
+func joined[L, R any](l monads.Option[L], r monads.Option[R]) Joined[L, R] {
+	return Joined[L, R]{Left: l, Right: r}
+}
+
+func joinMode(mode []JoinMode) JoinMode {
+	if len(mode) > 0 {
+		return mode[0]
+	}
+	return InnerJoin
+}
+
+// HashJoin joins the sequences by collecting the right sequence into a map,
+// then streaming the left sequence. Matches are yielded in the order of the
+// left sequence, and unmatched right elements are yielded at the end.
+func HashJoin[L, R any, K comparable](left Seq[L], right Seq[R], keyL func(L) K, keyR func(R) K, mode ...JoinMode) Seq2[K, Joined[L, R]] {
+	m := joinMode(mode)
+	return Seq2Func[K, Joined[L, R]](func(yield func(K, Joined[L, R]) bool) {
+		// Keys are kept in order so unmatched elements are yielded in order
+		var keys []K
+		table := make(map[K][]R)
+		for r := range right.Seq {
+			k := keyR(r)
+			if _, ok := table[k]; !ok {
+				keys = append(keys, k)
+			}
+			table[k] = append(table[k], r)
+		}
+
+		matched := make(map[K]bool)
+		ok := true
+		left.Seq(func(l L) bool {
+			k := keyL(l)
+			rs, found := table[k]
+			if !found {
+				if m >= LeftJoin {
+					ok = yield(k, joined(monads.Some(l), monads.None[R]()))
+				}
+				return ok
+			}
+			matched[k] = true
+			for _, r := range rs {
+				if ok = yield(k, joined(monads.Some(l), monads.Some(r))); !ok {
+					return false
+				}
+			}
+			return true
+		})
+
+		if !ok || m < OuterJoin {
+			return
+		}
+		for _, k := range keys {
+			if matched[k] {
+				continue
+			}
+			for _, r := range table[k] {
+				if !yield(k, joined(monads.None[L](), monads.Some(r))) {
+					return
+				}
+			}
+		}
+	})
+}
+
+// MergeJoin joins two sequences that are already sorted by key.
+// Unlike [HashJoin] it streams both sequences, only buffering the right
+// elements that share the current key.
+func MergeJoin[L, R any, K cmp.Ordered](left Seq[L], right Seq[R], keyL func(L) K, keyR func(R) K, mode ...JoinMode) Seq2[K, Joined[L, R]] {
+	m := joinMode(mode)
+	return Seq2Func[K, Joined[L, R]](func(yield func(K, Joined[L, R]) bool) {
+		next, stop := Pull(right)
+		defer stop()
+		r, rok := next()
+
+		var group []R
+		var groupKey K
+		hasGroup := false
+		ok := true
+
+		left.Seq(func(l L) bool {
+			k := keyL(l)
+			if !hasGroup || groupKey != k {
+				// Skip past the smaller right elements, which have no match
+				for rok && keyR(r) < k {
+					if m >= OuterJoin {
+						if ok = yield(keyR(r), joined(monads.None[L](), monads.Some(r))); !ok {
+							return false
+						}
+					}
+					r, rok = next()
+				}
+				group = group[:0]
+				for rok && keyR(r) == k {
+					group = append(group, r)
+					r, rok = next()
+				}
+				groupKey, hasGroup = k, true
+			}
+
+			if len(group) == 0 {
+				if m >= LeftJoin {
+					ok = yield(k, joined(monads.Some(l), monads.None[R]()))
+				}
+				return ok
+			}
+			for _, g := range group {
+				if ok = yield(k, joined(monads.Some(l), monads.Some(g))); !ok {
+					return false
+				}
+			}
+			return true
+		})
+
+		if !ok || m < OuterJoin {
+			return
+		}
+		for ; rok; r, rok = next() {
+			if !yield(keyR(r), joined(monads.None[L](), monads.Some(r))) {
+				return
+			}
+		}
+	})
+}
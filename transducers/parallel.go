@@ -0,0 +1,142 @@
+package transducers
+
+import (
+	"sync"
+
+	. "github.com/rushsteve1/fp"
+)
+
+// Parallel transducers spread their work across multiple goroutines.
+// The source sequence is always consumed on its own goroutine, so when the
+// downstream stops it will exit the next time the source yields a value.
+// The worker goroutines are always finished by the time the sequence returns.
+
+type parallelJob[T, U any] struct {
+	v   T
+	out chan U
+}
+
+// ParallelMap is like [Map] but runs f on up to workers goroutines at once.
+// Results are yielded in the same order as the original sequence.
+// A workers count less than 1 is treated as 1.
+func ParallelMap[T, U any](seq Seq[T], workers int, f Transform[T, U]) Seq[U] {
+	workers = max(workers, 1)
+	return SeqFunc[U](func(yield func(U) bool) {
+		jobs := make(chan parallelJob[T, U])
+		// Each element gets its own result channel, which are queued in order
+		order := make(chan chan U, workers)
+		done := make(chan struct{})
+
+		var wg sync.WaitGroup
+		defer func() {
+			close(done)
+			wg.Wait()
+		}()
+
+		for range workers {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for {
+					select {
+					case j, ok := <-jobs:
+						if !ok {
+							return
+						}
+						// Buffered so this never blocks
+						j.out <- f(j.v)
+					case <-done:
+						return
+					}
+				}
+			}()
+		}
+
+		go func() {
+			defer close(order)
+			defer close(jobs)
+			seq.Seq(func(t T) bool {
+				out := make(chan U, 1)
+				select {
+				case order <- out:
+				case <-done:
+					return false
+				}
+				select {
+				case jobs <- parallelJob[T, U]{t, out}:
+					return true
+				case <-done:
+					return false
+				}
+			})
+		}()
+
+		for out := range order {
+			if !yield(<-out) {
+				return
+			}
+		}
+	})
+}
+
+// ParallelMapUnordered is like [ParallelMap] but yields results as soon as
+// they are ready, regardless of the order of the original sequence.
+func ParallelMapUnordered[T, U any](seq Seq[T], workers int, f Transform[T, U]) Seq[U] {
+	workers = max(workers, 1)
+	return SeqFunc[U](func(yield func(U) bool) {
+		jobs := make(chan T)
+		results := make(chan U)
+		done := make(chan struct{})
+
+		var wg sync.WaitGroup
+		defer func() {
+			close(done)
+			wg.Wait()
+		}()
+
+		for range workers {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for {
+					select {
+					case t, ok := <-jobs:
+						if !ok {
+							return
+						}
+						select {
+						case results <- f(t):
+						case <-done:
+							return
+						}
+					case <-done:
+						return
+					}
+				}
+			}()
+		}
+
+		go func() {
+			defer close(jobs)
+			seq.Seq(func(t T) bool {
+				select {
+				case jobs <- t:
+					return true
+				case <-done:
+					return false
+				}
+			})
+		}()
+
+		go func() {
+			wg.Wait()
+			close(results)
+		}()
+
+		for u := range results {
+			if !yield(u) {
+				return
+			}
+		}
+	})
+}
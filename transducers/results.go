@@ -0,0 +1,62 @@
+package transducers
+
+import (
+	. "github.com/rushsteve1/fp"
+	"github.com/rushsteve1/fp/fun"
+	"github.com/rushsteve1/fp/monads"
+	"github.com/rushsteve1/fp/reducers"
+)
+
+// Result transducers work on sequences of [monads.Result] like those produced
+// by [Write] and [generators.Reader], operating on the ok values and passing
+// the errors along untouched.
+
+// MapOk is like [Map] but only transforms the ok values
+func MapOk[T, U any](seq Seq[monads.Result[T]], f Transform[T, U]) Seq[monads.Result[U]] {
+	return Map(seq, func(r monads.Result[T]) monads.Result[U] {
+		if r.Err != nil {
+			return monads.Result[U]{Err: r.Err}
+		}
+		return monads.Wrap(f(r.V), nil)
+	})
+}
+
+// FilterOk is like [Filter] but only filters the ok values, errors are kept
+func FilterOk[T any](seq Seq[monads.Result[T]], f fun.Predicate[T]) Seq[monads.Result[T]] {
+	return SeqFunc[monads.Result[T]](func(yield func(monads.Result[T]) bool) {
+		seq.Seq(func(r monads.Result[T]) bool {
+			if r.Err != nil || f(r.V) {
+				return yield(r)
+			}
+			return true
+		})
+	})
+}
+
+// StopOnErr yields results up to and including the first error, then stops
+func StopOnErr[T any](seq Seq[monads.Result[T]]) Seq[monads.Result[T]] {
+	return SeqFunc[monads.Result[T]](func(yield func(monads.Result[T]) bool) {
+		seq.Seq(func(r monads.Result[T]) bool {
+			return yield(r) && r.Err == nil
+		})
+	})
+}
+
+// TransduceE is like [Transduce] for transducers that return results.
+// The collector is passed the ok values, and the first error stops the whole
+// pipeline and is returned along with the zero value.
+func TransduceE[T, U, V any](src Seq[T], tx Transducer[T, monads.Result[U]], rx reducers.Collector[U, V]) (out V, err error) {
+	v := rx(SeqFunc[U](func(yield func(U) bool) {
+		StopOnErr(tx(src)).Seq(func(r monads.Result[U]) bool {
+			if r.Err != nil {
+				err = r.Err
+				return false
+			}
+			return yield(r.V)
+		})
+	}))
+	if err != nil {
+		return out, err
+	}
+	return v, nil
+}
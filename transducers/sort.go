@@ -0,0 +1,168 @@
+package transducers
+
+import (
+	"bufio"
+	"encoding/gob"
+	"errors"
+	"io"
+	"os"
+	"slices"
+
+	. "github.com/rushsteve1/fp"
+	"github.com/rushsteve1/fp/reducers"
+)
+
+// Sorting transducers have to see every element before yielding any, so they
+// cannot be used with infinite sequences.
+
+// Sort collects the sequence and yields it sorted by the comparison
+func Sort[T any](seq Seq[T], cmp func(T, T) int) Seq[T] {
+	return SeqFunc[T](func(yield func(T) bool) {
+		for _, t := range reducers.IntoSorted(seq, cmp) {
+			if !yield(t) {
+				return
+			}
+		}
+	})
+}
+
+// TopK yields the count largest elements, largest first.
+// Only count elements are kept in memory at a time using a [reducers.Heap].
+func TopK[T any](seq Seq[T], count int, cmp func(T, T) int) Seq[T] {
+	return SeqFunc[T](func(yield func(T) bool) {
+		// The smallest of the current top is on top of the heap
+		h := reducers.NewHeap(cmp)
+		for v := range seq.Seq {
+			h.Add(v)
+			if h.Len() > count {
+				h.Pop()
+			}
+		}
+		out := reducers.Collect[T](h)
+		slices.Reverse(out)
+		for _, t := range out {
+			if !yield(t) {
+				return
+			}
+		}
+	})
+}
+
+// BottomK yields the count smallest elements, smallest first
+func BottomK[T any](seq Seq[T], count int, cmp func(T, T) int) Seq[T] {
+	return TopK(seq, count, func(a, b T) int {
+		return cmp(b, a)
+	})
+}
+
+// Codec encodes and decodes a stream of values, used by [SortSpill]
+type Codec[T any] struct {
+	NewEncoder func(io.Writer) func(T) error
+	// The decoder must return [io.EOF] at the end of the stream
+	NewDecoder func(io.Reader) func() (T, error)
+}
+
+// GobCodec is a [Codec] using [encoding/gob]
+func GobCodec[T any]() Codec[T] {
+	return Codec[T]{
+		NewEncoder: func(w io.Writer) func(T) error {
+			enc := gob.NewEncoder(w)
+			return func(t T) error {
+				return enc.Encode(t)
+			}
+		},
+		NewDecoder: func(r io.Reader) func() (out T, err error) {
+			dec := gob.NewDecoder(r)
+			return func() (out T, err error) {
+				err = dec.Decode(&out)
+				return out, err
+			}
+		},
+	}
+}
+
+// SpillRunSize is the number of elements [SortSpill] sorts in memory before
+// writing them to a temporary file
+var SpillRunSize = 1 << 16
+
+// SortSpill is like [Sort] but for sequences larger than memory.
+// Runs of [SpillRunSize] elements are sorted and written to temporary files in
+// dir using the codec, then merged together as the result is yielded.
+// If dir is empty the default from [os.TempDir] is used.
+//
+// The temporary files are removed once the sequence stops.
+// I/O errors are passed to [Check].
+func SortSpill[T any](seq Seq[T], cmp func(T, T) int, codec Codec[T], dir string) Seq[T] {
+	return SeqFunc[T](func(yield func(T) bool) {
+		var runs []*os.File
+		defer func() {
+			for _, f := range runs {
+				f.Close()
+				os.Remove(f.Name())
+			}
+		}()
+
+		buf := make([]T, 0, SpillRunSize)
+		spill := func() {
+			slices.SortFunc(buf, cmp)
+			f := Must(os.CreateTemp(dir, "fp-sort-*"))
+			runs = append(runs, f)
+			w := bufio.NewWriter(f)
+			enc := codec.NewEncoder(w)
+			for _, t := range buf {
+				Check(enc(t))
+			}
+			Check(w.Flush())
+			buf = buf[:0]
+		}
+
+		for v := range seq.Seq {
+			buf = append(buf, v)
+			if len(buf) == SpillRunSize {
+				spill()
+			}
+		}
+
+		// Everything fit in memory so there is no need to merge
+		if len(runs) == 0 {
+			slices.SortFunc(buf, cmp)
+			for _, t := range buf {
+				if !yield(t) {
+					return
+				}
+			}
+			return
+		}
+		if len(buf) > 0 {
+			spill()
+		}
+
+		type head struct {
+			v    T
+			next func() (T, error)
+		}
+		h := reducers.NewHeap(func(a, b head) int {
+			return cmp(a.v, b.v)
+		})
+		push := func(next func() (T, error)) {
+			v, err := next()
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			Check(err)
+			h.Add(head{v, next})
+		}
+
+		for _, f := range runs {
+			Must(f.Seek(0, io.SeekStart))
+			push(codec.NewDecoder(bufio.NewReader(f)))
+		}
+		for h.Len() > 0 {
+			top := h.Pop().V
+			if !yield(top.v) {
+				return
+			}
+			push(top.next)
+		}
+	})
+}
@@ -3,7 +3,12 @@
 package transducers
 
 import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
 	"io"
+	"slices"
+	"sync"
 	"time"
 
 	. "github.com/rushsteve1/fp"
@@ -11,6 +16,7 @@ import (
 	"github.com/rushsteve1/fp/generators"
 	"github.com/rushsteve1/fp/monads"
 	"github.com/rushsteve1/fp/reducers"
+	"github.com/rushsteve1/fp/sketches"
 )
 
 // Most of these type definitions are for illustrative purposes and are unnecessary
@@ -48,6 +54,34 @@ func Map[T, U any](seq Seq[T], f Transform[T, U]) Seq[U] {
 	})
 }
 
+// FlatMap is like [Map] but f returns a sequence, all of which are yielded.
+// Since all monads are sequences this can also be used to unwrap them,
+// dropping invalid [monads.Option] and failed [monads.Result] values.
+func FlatMap[T, U any](seq Seq[T], f Transform[T, Seq[U]]) Seq[U] {
+	return SeqFunc[U](func(yield func(U) bool) {
+		seq.Seq(func(t T) bool {
+			ok := true
+			f(t).Seq(func(u U) bool {
+				ok = yield(u)
+				return ok
+			})
+			return ok
+		})
+	})
+}
+
+// Flatten yields all the elements of each sequence in turn
+func Flatten[T any](seq Seq[Seq[T]]) Seq[T] {
+	return FlatMap(seq, fun.Identity[Seq[T]])
+}
+
+// Mapcat is [FlatMap] but for functions that return slices
+func Mapcat[T, U any](seq Seq[T], f Transform[T, []U]) Seq[U] {
+	return FlatMap(seq, func(t T) Seq[U] {
+		return SeqFunc[U](slices.Values(f(t)))
+	})
+}
+
 // Filter has the added constraint [comparable] but only needs one generic
 func Filter[T comparable](seq Seq[T], f fun.Predicate[T]) Seq[T] {
 	return SeqFunc[T](func(yield func(T) bool) {
@@ -57,6 +91,106 @@ func Filter[T comparable](seq Seq[T], f fun.Predicate[T]) Seq[T] {
 	})
 }
 
+// SplitBufSize is the number of elements each sequence returned by [Split]
+// can buffer for the other before it blocks
+var SplitBufSize = 16
+
+// Split is a lazy version of [reducers.Partition], returning a sequence of
+// the elements that pass the predicate and a sequence of those that do not.
+//
+// Both sequences share a single pull from the original sequence, buffering
+// up to [SplitBufSize] elements meant for the other, so it is only consumed
+// once. When that buffer is full pulling blocks until the other sequence
+// catches up, so consuming more than that from one sequence without the
+// other only works on separate goroutines.
+// The two sequences are safe to consume on different goroutines.
+//
+// Each sequence can only be consumed once, and once one stops the elements
+// meant for it are dropped. The original sequence is only stopped once both
+// have, so the other sequence must always be drained or stopped by breaking
+// out of it, otherwise the original sequence is leaked.
+func Split[T any](seq Seq[T], f fun.Predicate[T]) (Seq[T], Seq[T]) {
+	var mu sync.Mutex
+	cond := sync.NewCond(&mu)
+	var next func() (T, bool)
+	var stop func()
+	var bufs [2][]T
+	var stopped [2]bool
+	// Only one side pulls at a time so elements stay in order
+	pulling := false
+	ended := false
+
+	pull := func(side int) (out T, ok bool) {
+		mu.Lock()
+		defer mu.Unlock()
+		if stopped[side] {
+			return out, false
+		}
+		for {
+			if len(bufs[side]) > 0 {
+				out, bufs[side] = bufs[side][0], bufs[side][1:]
+				cond.Broadcast()
+				return out, true
+			}
+			if ended {
+				return out, false
+			}
+			if pulling {
+				cond.Wait()
+				continue
+			}
+			if next == nil {
+				next, stop = Pull(seq)
+			}
+
+			t, ok := next()
+			if !ok {
+				ended = true
+				stop()
+				cond.Broadcast()
+				return out, false
+			}
+			s := Ternary(f(t), 0, 1)
+			if s == side {
+				return t, true
+			}
+			pulling = true
+			for len(bufs[s]) >= SplitBufSize && !stopped[s] {
+				cond.Wait()
+			}
+			pulling = false
+			if !stopped[s] {
+				bufs[s] = append(bufs[s], t)
+			}
+			cond.Broadcast()
+		}
+	}
+
+	side := func(side int) Seq[T] {
+		return SeqFunc[T](func(yield func(T) bool) {
+			defer func() {
+				mu.Lock()
+				defer mu.Unlock()
+				stopped[side] = true
+				bufs[side] = nil
+				cond.Broadcast()
+				if stopped[0] && stopped[1] && !ended && stop != nil {
+					ended = true
+					stop()
+				}
+			}()
+			for {
+				t, ok := pull(side)
+				if !ok || !yield(t) {
+					return
+				}
+			}
+		})
+	}
+
+	return side(0), side(1)
+}
+
 // Each can be trivially defined using [Map]
 func Each[T any](seq Seq[T], f func(T)) Seq[T] {
 	return Map[T, T](seq, func(t T) T {
@@ -152,6 +286,15 @@ func Fuse[T Nilable](seq Seq[T], count int) Seq[T] {
 	})
 }
 
+// WithContext stops the sequence once the context is cancelled.
+// The context is only checked when an element is yielded, so blocking sources
+// should use the context-aware generators like [generators.TickerCtx].
+func WithContext[T any](seq Seq[T], ctx context.Context) Seq[T] {
+	return TakeWhile(seq, func(T) bool {
+		return ctx.Err() == nil
+	})
+}
+
 // Debounce only yields values if the current element was yielded at least delay
 // time since the last value was yielded.
 // Elements that happen in-between debounces are dropped.
@@ -199,6 +342,18 @@ func TimeDelta(seq Seq[time.Time]) Seq[time.Duration] {
 	})
 }
 
+// Scan is like [reducers.Reduce] but yields every intermediate accumulator
+// instead of only the final one. The initial value is not yielded.
+func Scan[T, Acc any](seq Seq[T], a Acc, f reducers.Reduction[T, Acc]) Seq[Acc] {
+	return SeqFunc[Acc](func(yield func(Acc) bool) {
+		acc := a
+		seq.Seq(func(t T) bool {
+			acc = f(t, acc)
+			return yield(acc)
+		})
+	})
+}
+
 // Enumerate returns a new [Seq2] with indices as keys
 func Enumerate[T any](seq Seq[T]) Seq2[int, T] {
 	return Seq2Func[int, T](func(yield func(int, T) bool) {
@@ -233,6 +388,30 @@ func Write(seq Seq[[]byte], w io.Writer) Seq[monads.Result[int]] {
 	})
 }
 
+// EncodeJSON writes every element to the writer as newline-delimited JSON,
+// yielding each element along with any error from encoding it.
+// See its counterpart [generators.JSONStream]
+func EncodeJSON[T any](seq Seq[T], w io.Writer) Seq[monads.Result[T]] {
+	enc := json.NewEncoder(w)
+	return Map(seq, func(t T) monads.Result[T] {
+		return monads.Wrap(t, enc.Encode(t))
+	})
+}
+
+// WriteCSV writes every record to the writer as CSV,
+// yielding each record along with any error from writing it.
+// See its counterpart [generators.CSV]
+func WriteCSV(seq Seq[[]string], w io.Writer) Seq[monads.Result[[]string]] {
+	cw := csv.NewWriter(w)
+	return Map(seq, func(rec []string) monads.Result[[]string] {
+		if err := cw.Write(rec); err != nil {
+			return monads.Wrap(rec, err)
+		}
+		cw.Flush()
+		return monads.Wrap(rec, cw.Error())
+	})
+}
+
 // Dedup removes equal adjacent elements from the stream
 func Dedup[T comparable](seq Seq[T]) Seq[T] {
 	return SeqFunc[T](func(yield func(T) bool) {
@@ -277,3 +456,19 @@ func Unique[T comparable](seq Seq[T]) Seq[T] {
 		})
 	})
 }
+
+// UniqueApprox is like [Unique] but uses a [sketches.Bloom] filter sized for
+// n distinct elements, so memory use does not grow with the sequence.
+// Unique elements are dropped with a probability of at most p.
+func UniqueApprox[T comparable](seq Seq[T], n int, p float64) Seq[T] {
+	return SeqFunc[T](func(yield func(T) bool) {
+		seen := sketches.NewBloom[T](n, p)
+		seq.Seq(func(t T) bool {
+			if !seen.Has(t) {
+				seen.Add(t)
+				return yield(t)
+			}
+			return true
+		})
+	})
+}
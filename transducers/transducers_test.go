@@ -2,7 +2,13 @@ package transducers_test
 
 import (
 	"bytes"
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
 	"io"
+	"math"
+	"os"
 	"slices"
 	"strconv"
 	"testing"
@@ -10,41 +16,42 @@ import (
 
 	. "github.com/rushsteve1/fp"
 	. "github.com/rushsteve1/fp/fun"
-	. "github.com/rushsteve1/fp/generators"
-	. "github.com/rushsteve1/fp/reducers"
+	"github.com/rushsteve1/fp/generators"
+	"github.com/rushsteve1/fp/monads"
+	"github.com/rushsteve1/fp/reducers"
 	. "github.com/rushsteve1/fp/transducers"
 )
 
 func TestTransduce(t *testing.T) {
 	s := Transduce(
-		Integers(),
+		generators.Integers(),
 		Chain3(
 			Curry2(Take[int], 5),
 			Curry2(Map, func(x int) int { return x + 1 }),
 			Curry2(Map, strconv.Itoa),
 		),
-		Max,
+		reducers.Max,
 	)
 	AssertEq(t, s, "5")
 }
 
 func BenchmarkTransduce(b *testing.B) {
 	Transduce(
-		Integers(),
+		generators.Integers(),
 		Chain3(
 			Curry2(Take[int], 5),
 			Curry2(Map, func(x int) int { return x + 1 }),
 			Curry2(Map, strconv.Itoa),
 		),
-		Max,
+		reducers.Max,
 	)
 }
 
 func TestTransducerSeconds(t *testing.T) {
 	Transduce(
-		Ticker(time.Second),
+		generators.Ticker(time.Second),
 		Curry2(Take[time.Time], 5),
-		Collect,
+		reducers.Collect,
 	)
 }
 
@@ -57,19 +64,19 @@ func TestMap(t *testing.T) {
 		return strconv.Itoa(x)
 	})
 
-	AssertSliceEq(t, Collect(tx2), []string{"2", "4", "6"})
+	AssertSliceEq(t, reducers.Collect(tx2), []string{"2", "4", "6"})
 }
 
 func TestTake(t *testing.T) {
 	seq := SeqFunc[int](slices.Values([]int{1, 2, 3, 4, 5}))
-	ar := Collect(Take(seq, 3))
+	ar := reducers.Collect(Take(seq, 3))
 	if !slices.Equal(ar, []int{1, 2, 3}) {
 		t.Errorf("%v is not right", ar)
 	}
 }
 
 func TestTakeInfinite(t *testing.T) {
-	ar := Collect(Take(Integers(), 5))
+	ar := reducers.Collect(Take(generators.Integers(), 5))
 	if !slices.Equal(ar, []int{0, 1, 2, 3, 4}) {
 		t.Errorf("%v is not right", ar)
 	}
@@ -77,26 +84,26 @@ func TestTakeInfinite(t *testing.T) {
 
 func TestTakeTransducer(t *testing.T) {
 	c := Curry2(Take[int], 5)
-	c(Seq[int](Integers()))
+	c(Seq[int](generators.Integers()))
 }
 
 func TestDebounce(t *testing.T) {
-	seq := Ticker(100 * time.Millisecond)
+	seq := generators.Ticker(100 * time.Millisecond)
 	seq = Debounce(seq, 1*time.Second)
 	seq = Take(seq, 5)
-	ar := Collect(seq)
+	ar := reducers.Collect(seq)
 	AssertEq(t, len(ar), 5)
 }
 
 func TestDebounceTransducer(t *testing.T) {
 	avg := Transduce(
-		Ticker(100*time.Millisecond),
+		generators.Ticker(100*time.Millisecond),
 		Chain3(
 			Curry2(Debounce[time.Time], 1*time.Second),
 			Curry2(Take[time.Time], 5),
 			TimeDelta,
 		),
-		Average,
+		reducers.Average,
 	)
 	AssertEq(t, int(avg.Seconds()), 1)
 }
@@ -104,7 +111,7 @@ func TestDebounceTransducer(t *testing.T) {
 func TestWriter(t *testing.T) {
 	var buf bytes.Buffer
 	ar := Transduce(
-		Integers(),
+		generators.Integers(),
 		Chain4(
 			Curry2(Take[int], 5),
 			Curry2(Map, func(i int) []byte {
@@ -115,9 +122,579 @@ func TestWriter(t *testing.T) {
 				return string(b)
 			}),
 		),
-		Collect,
+		reducers.Collect,
 	)
 	t.Log(buf)
 	t.Log(ar)
 	AssertEq(t, buf.Len(), len(ar))
 }
+
+func TestChunk(t *testing.T) {
+	seq := SeqFunc[int](slices.Values([]int{1, 2, 3, 4, 5}))
+	ar := reducers.Collect(Chunk(seq, 2))
+	AssertEq(t, fmt.Sprint(ar), "[[1 2] [3 4] [5]]")
+}
+
+func TestChunkInfinite(t *testing.T) {
+	ar := Transduce(
+		generators.Integers(),
+		Chain2(
+			Curry2(Chunk[int], 3),
+			Curry2(Take[[]int], 2),
+		),
+		reducers.Collect,
+	)
+	AssertEq(t, fmt.Sprint(ar), "[[0 1 2] [3 4 5]]")
+}
+
+func TestSlidingWindow(t *testing.T) {
+	seq := SeqFunc[int](slices.Values([]int{1, 2, 3, 4, 5, 6}))
+	AssertEq(t, fmt.Sprint(reducers.Collect(SlidingWindow(seq, 3, 1))), "[[1 2 3] [2 3 4] [3 4 5] [4 5 6]]")
+	AssertEq(t, fmt.Sprint(reducers.Collect(SlidingWindow(seq, 3, 2))), "[[1 2 3] [3 4 5] [5 6]]")
+	AssertEq(t, fmt.Sprint(reducers.Collect(SlidingWindow(seq, 2, 3))), "[[1 2] [4 5]]")
+	AssertEq(t, fmt.Sprint(reducers.Collect(SlidingWindow(Take(seq, 3), -1, 0))), "[[1] [2] [3]]")
+	AssertEq(t, fmt.Sprint(reducers.Collect(Chunk(Take(seq, 2), -5))), "[[1] [2]]")
+}
+
+func TestPartitionBy(t *testing.T) {
+	seq := SeqFunc[int](slices.Values([]int{1, 3, 2, 4, 5, 7, 6}))
+	ar := reducers.Collect(PartitionBy(seq, func(x int) bool { return x%2 == 0 }))
+	AssertEq(t, fmt.Sprint(ar), "[[1 3] [2 4] [5 7] [6]]")
+}
+
+func TestBufferTimeSize(t *testing.T) {
+	ar := reducers.Collect(Take(BufferTime(generators.Integers(), 3, time.Hour), 2))
+	AssertEq(t, fmt.Sprint(ar), "[[0 1 2] [3 4 5]]")
+}
+
+func TestBufferTimeWait(t *testing.T) {
+	ar := Transduce(
+		generators.Ticker(10*time.Millisecond),
+		Chain2(
+			Curry3(BufferTime[time.Time], 100, 55*time.Millisecond),
+			Curry2(Take[[]time.Time], 3),
+		),
+		reducers.Collect,
+	)
+	AssertEq(t, len(ar), 3)
+	for _, b := range ar {
+		Assert(t, len(b) > 0 && len(b) < 100)
+	}
+}
+
+func TestBufferTimeFlush(t *testing.T) {
+	seq := SeqFunc[int](slices.Values([]int{1, 2, 3, 4, 5}))
+	ar := reducers.Collect(BufferTime(seq, 2, time.Hour))
+	AssertEq(t, fmt.Sprint(ar), "[[1 2] [3 4] [5]]")
+	AssertEq(t, len(reducers.Collect(BufferTime(seq, -1, time.Hour))), 5)
+	AssertEq(t, fmt.Sprint(reducers.Collect(BufferTime(seq, 2, 0))), "[[1 2] [3 4] [5]]")
+}
+
+func TestParallelMap(t *testing.T) {
+	seq := SeqFunc[int](slices.Values([]int{5, 1, 4, 2, 3}))
+	ar := reducers.Collect(ParallelMap(seq, 3, func(x int) int {
+		time.Sleep(time.Duration(x) * time.Millisecond)
+		return x * 2
+	}))
+	AssertSliceEq(t, ar, []int{10, 2, 8, 4, 6})
+}
+
+func TestParallelMapInfinite(t *testing.T) {
+	ar := Transduce(
+		generators.Integers(),
+		Chain2(
+			Curry3(ParallelMap[int, string], 4, strconv.Itoa),
+			Curry2(Take[string], 5),
+		),
+		reducers.Collect,
+	)
+	AssertSliceEq(t, ar, []string{"0", "1", "2", "3", "4"})
+
+	AssertSliceEq(t, reducers.Collect(Take(ParallelMap(generators.Integers(), 0, Identity), 3)), []int{0, 1, 2})
+	AssertSliceEq(t, reducers.Collect(Take(ParallelMap(generators.Integers(), -2, Identity), 3)), []int{0, 1, 2})
+}
+
+func TestParallelMapUnordered(t *testing.T) {
+	seq := SeqFunc[int](slices.Values([]int{5, 1, 4, 2, 3}))
+	ar := reducers.Collect(ParallelMapUnordered(seq, 3, func(x int) int {
+		time.Sleep(time.Duration(x) * time.Millisecond)
+		return x * 2
+	}))
+	slices.Sort(ar)
+	AssertSliceEq(t, ar, []int{2, 4, 6, 8, 10})
+	AssertEq(t, len(reducers.Collect(Take(ParallelMapUnordered(generators.Integers(), 4, Identity), 5))), 5)
+	AssertEq(t, len(reducers.Collect(Take(ParallelMapUnordered(generators.Integers(), 0, Identity), 5))), 5)
+}
+
+func TestWithContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	ar := Transduce(
+		generators.Integers(),
+		Chain2(
+			Curry2(Each[int], func(x int) {
+				if x == 4 {
+					cancel()
+				}
+			}),
+			Curry2(WithContext[int], ctx),
+		),
+		reducers.Collect,
+	)
+	AssertSliceEq(t, ar, []int{0, 1, 2, 3})
+}
+
+func TestWithContextTicker(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	i := 0
+	ar := Transduce(
+		generators.TickerCtx(ctx, 10*time.Millisecond),
+		Chain2(
+			Curry2(Each[time.Time], func(time.Time) {
+				if i++; i == 3 {
+					cancel()
+				}
+			}),
+			Curry2(WithContext[time.Time], ctx),
+		),
+		reducers.Collect,
+	)
+	AssertEq(t, len(ar), 2)
+}
+
+var errOdd = errors.New("odd")
+
+func checkEven(x int) monads.Result[int] {
+	if x%2 != 0 {
+		return monads.Wrap(x, errOdd)
+	}
+	return monads.Wrap(x, nil)
+}
+
+func TestMapOk(t *testing.T) {
+	seq := SeqFunc[int](slices.Values([]int{2, 3, 4}))
+	r := Transduce(
+		seq,
+		Chain3(
+			Curry2(Map, checkEven),
+			Curry2(MapOk, func(x int) int { return x * 10 }),
+			Curry2(FilterOk, func(x int) bool { return x > 20 }),
+		),
+		reducers.CollectErrs,
+	)
+	AssertSliceEq(t, r.V, []int{40})
+	Assert(t, errors.Is(r.Err, errOdd))
+}
+
+func TestTransduceE(t *testing.T) {
+	ar, err := TransduceE(
+		generators.Integers(),
+		Chain2(
+			Curry2(Take[int], 3),
+			Curry2(Map, func(x int) monads.Result[int] { return monads.Wrap(x*2, nil) }),
+		),
+		reducers.Collect,
+	)
+	AssertSliceEq(t, ar, []int{0, 2, 4})
+	AssertEq(t, err, nil)
+
+	seen := 0
+	_, err = TransduceE(
+		generators.Integers(),
+		Chain2(
+			Curry2(Each[int], func(int) { seen++ }),
+			Curry2(Map, checkEven),
+		),
+		reducers.Collect,
+	)
+	AssertEq(t, err, errOdd)
+	AssertEq(t, seen, 2)
+}
+
+func TestZip(t *testing.T) {
+	seq := SeqFunc[string](slices.Values([]string{"a", "b", "c"}))
+	m := reducers.Collect2(Zip(seq, generators.Integers()))
+	AssertEq(t, len(m), 3)
+	AssertEq(t, m["c"], 2)
+
+	sums := reducers.Collect(ZipWith(generators.Integers(), Take(generators.Integers(), 3), func(a, b int) int {
+		return a + b
+	}))
+	AssertSliceEq(t, sums, []int{0, 2, 4})
+}
+
+func TestInterleave(t *testing.T) {
+	a := SeqFunc[int](slices.Values([]int{1, 2, 3, 4}))
+	b := SeqFunc[int](slices.Values([]int{10, 20}))
+	AssertSliceEq(t, reducers.Collect(Interleave(a, b, generators.Empty[int]())), []int{1, 10, 2, 20, 3, 4})
+	AssertSliceEq(t, reducers.Collect(Take(Interleave(generators.Integers(), generators.Forever(-1)), 4)), []int{0, -1, 1, -1})
+}
+
+func TestMerge(t *testing.T) {
+	a := SeqFunc[int](slices.Values([]int{1, 2, 3}))
+	b := SeqFunc[int](slices.Values([]int{10, 20}))
+	ar := reducers.Collect(Merge(a, b))
+	slices.Sort(ar)
+	AssertSliceEq(t, ar, []int{1, 2, 3, 10, 20})
+
+	AssertEq(t, len(reducers.Collect(Take(Merge(generators.Ticker(10*time.Millisecond), generators.Ticker(15*time.Millisecond)), 4))), 4)
+}
+
+func TestFlatMap(t *testing.T) {
+	ar := Transduce(
+		generators.Integers(),
+		Chain2(
+			Curry2(FlatMap, func(x int) Seq[int] { return Take(generators.Forever(x), x) }),
+			Curry2(Take[int], 6),
+		),
+		reducers.Collect,
+	)
+	AssertSliceEq(t, ar, []int{1, 2, 2, 3, 3, 3})
+}
+
+func TestFlatten(t *testing.T) {
+	seq := SeqFunc[Seq[int]](slices.Values([]Seq[int]{generators.Once(1), generators.Empty[int](), Take(generators.Integers(), 2)}))
+	AssertSliceEq(t, reducers.Collect(Flatten(seq)), []int{1, 0, 1})
+
+	opts := SeqFunc[monads.Option[int]](slices.Values([]monads.Option[int]{
+		monads.Some(1), monads.None[int](), monads.Some(3),
+	}))
+	AssertSliceEq(t, reducers.Collect(FlatMap(opts, func(o monads.Option[int]) Seq[int] { return o })), []int{1, 3})
+}
+
+func TestMapcat(t *testing.T) {
+	seq := SeqFunc[string](slices.Values([]string{"ab", "", "c"}))
+	ar := reducers.Collect(Mapcat(seq, func(s string) []byte { return []byte(s) }))
+	AssertEq(t, string(ar), "abc")
+}
+
+func TestScan(t *testing.T) {
+	ar := Transduce(
+		generators.Integers(),
+		Chain2(
+			Curry2(Take[int], 5),
+			Curry3(Scan[int, int], 0, func(x, acc int) int { return acc + x }),
+		),
+		reducers.Collect,
+	)
+	AssertSliceEq(t, ar, []int{0, 1, 3, 6, 10})
+}
+
+func TestGroupBy(t *testing.T) {
+	words := SeqFunc[string](slices.Values([]string{"apple", "avocado", "banana", "cherry", "blueberry"}))
+	first := func(s string) byte { return s[0] }
+
+	groups := reducers.GroupBy(words, first)
+	AssertSliceEq(t, groups['b'], []string{"banana", "blueberry"})
+
+	lens := reducers.GroupReduce(words, first, 0, func(s string, acc int) int { return acc + len(s) })
+	AssertEq(t, lens['a'], 12)
+
+	runs := reducers.Collect[KeyValue[byte, []string]](GroupAdjacent(words, first))
+	AssertEq(t, len(runs), 4)
+	AssertEq(t, runs[0].Key, 'a')
+	AssertSliceEq(t, runs[0].Value, []string{"apple", "avocado"})
+}
+
+func TestTee(t *testing.T) {
+	stats := Transduce(
+		generators.Integers(),
+		Chain2(
+			Curry2(Drop[int], 1),
+			Curry2(Take[int], 5),
+		),
+		reducers.Tee4(reducers.Min[int], reducers.Max[int], reducers.Length[int], reducers.Collect[int]),
+	)
+	AssertEq(t, stats.A, 0)
+	AssertEq(t, stats.B, 5)
+	AssertEq(t, stats.C, 5)
+	AssertSliceEq(t, stats.D, []int{1, 2, 3, 4, 5})
+
+	seen := 0
+	found := reducers.Tee(
+		Curry2(reducers.Any[int], func(x int) bool { return x == 1 }),
+		Curry2(reducers.Any[int], func(x int) bool { return x == 3 }),
+	)(Each(generators.Integers(), func(int) { seen++ }))
+	AssertSliceEq(t, found, []bool{true, true})
+	AssertEq(t, seen, 4)
+}
+
+func TestUniqueApprox(t *testing.T) {
+	ar := Transduce(
+		generators.Integers(),
+		Chain3(
+			Curry2(Take[int], 1000),
+			Curry2(Map, func(x int) int { return x % 100 }),
+			Curry3(UniqueApprox[int], 100, 0.001),
+		),
+		reducers.Collect,
+	)
+	Assert(t, len(ar) > 95 && len(ar) <= 100)
+}
+
+func TestOptReducers(t *testing.T) {
+	AssertEq(t, reducers.FirstOpt(Drop(generators.Integers(), 3)), monads.Some(3))
+	AssertEq(t, reducers.First(generators.Integers()), 0)
+	AssertEq(t, reducers.Index(generators.Integers(), 4), monads.Some(4))
+
+	seq := SeqFunc[int](slices.Values([]int{3, -1, 4, -1, 5}))
+	AssertEq(t, reducers.LastOpt(seq), monads.Some(5))
+	AssertEq(t, reducers.MaxOpt(seq), monads.Some(5))
+	AssertEq(t, reducers.MinOpt(seq), monads.Some(-1))
+	AssertEq(t, reducers.AverageOpt(seq), monads.Some(2))
+
+	empty := generators.Empty[int]()
+	AssertEq(t, reducers.LastOpt(empty), monads.None[int]())
+	AssertEq(t, reducers.MaxOpt(empty), monads.None[int]())
+	AssertEq(t, reducers.AverageOpt(empty), monads.None[int]())
+
+	words := SeqFunc[string](slices.Values([]string{"bb", "a", "ccc", "dd"}))
+	byLen := func(a, b string) int { return len(a) - len(b) }
+	AssertEq(t, reducers.MaxBy(words, byLen), monads.Some("ccc"))
+	AssertEq(t, reducers.MinBy(words, byLen), monads.Some("a"))
+}
+
+func TestParallelReduce(t *testing.T) {
+	sum := Transduce(
+		generators.Integers(),
+		Curry2(Take[int], 10000),
+		Curry3(reducers.ParallelReduce[int], reducers.SumMonoid[int](), 4),
+	)
+	AssertEq(t, sum, 49995000)
+	AssertEq(t, reducers.Fold(Take(generators.Integers(), 5), reducers.ProductMonoid[int]()), 0)
+	AssertEq(t, reducers.ParallelReduce(Drop(Take(generators.Integers(), 5000), 1), reducers.MinMonoid(math.MaxInt), 3), 1)
+	AssertEq(t, reducers.ParallelReduce(Take(generators.Integers(), 5000), reducers.MaxMonoid(math.MinInt), 3), 4999)
+
+	chunks := Chunk(Take(generators.Integers(), 3000), 7)
+	AssertSliceEq(t, reducers.ParallelReduce(chunks, reducers.ConcatMonoid[int](), 4), reducers.Collect(Take(generators.Integers(), 3000)))
+
+	maps := Map(Take(generators.Integers(), 3000), func(x int) map[int]int {
+		return map[int]int{x % 3: 1}
+	})
+	counts := reducers.ParallelReduce(maps, reducers.MergeMonoid[int](func(a, b int) int { return a + b }), 4)
+	AssertEq(t, counts[2], 1000)
+}
+
+func TestReduceWhile(t *testing.T) {
+	// Find the first triangle number over 100
+	tri := reducers.FoldUntil(generators.Integers(), 0, func(x, acc int) int { return acc + x }, func(acc int) bool {
+		return acc > 100
+	})
+	AssertEq(t, tri, 105)
+
+	seen := 0
+	found := reducers.ReduceWhile(Each(generators.Integers(), func(int) { seen++ }), -1, func(x, acc int) (int, bool) {
+		if x*x > 50 {
+			return x, false
+		}
+		return acc, true
+	})
+	AssertEq(t, found, 8)
+	AssertEq(t, seen, 9)
+}
+
+func TestSinks(t *testing.T) {
+	seq := SeqFunc[int](slices.Values([]int{3, 1, 4, 1, 5}))
+
+	set := reducers.IntoSet(seq)
+	AssertEq(t, len(set), 4)
+	Assert(t, set.Has(4) && !set.Has(2))
+
+	AssertSliceEq(t, reducers.IntoSorted(seq, cmp.Compare[int]), []int{1, 1, 3, 4, 5})
+	AssertSliceEq(t, reducers.Collect(reducers.IntoHeap(seq, cmp.Compare[int])), []int{1, 1, 3, 4, 5})
+	AssertEq(t, reducers.Join(Map(seq, strconv.Itoa), ", "), "3, 1, 4, 1, 5")
+
+	var buf bytes.Buffer
+	err := Transduce(seq, Curry2(Take[int], 2), Curry3(reducers.IntoWriter[int], io.Writer(&buf), func(x int) []byte {
+		return []byte(strconv.Itoa(x))
+	}))
+	AssertEq(t, err, nil)
+	AssertEq(t, buf.String(), "31")
+
+	c := make(chan int)
+	go reducers.IntoChan(seq, c)
+	AssertSliceEq(t, reducers.Collect(generators.Chan(c)), []int{3, 1, 4, 1, 5})
+
+	sum := 0
+	reducers.Into(seq, reducers.SinkFunc[int](func(x int) { sum += x }))
+	AssertEq(t, sum, 14)
+}
+
+func TestPartition(t *testing.T) {
+	isEven := func(x int) bool { return x%2 == 0 }
+	seq := SeqFunc[int](slices.Values([]int{1, 2, 3, 4, 5}))
+	yes, no := reducers.Partition(seq, isEven)
+	AssertSliceEq(t, yes, []int{2, 4})
+	AssertSliceEq(t, no, []int{1, 3, 5})
+
+	c := make(chan int)
+	go reducers.IntoChan(Take(generators.Integers(), 10), c)
+	evens, odds := Split(generators.Chan(c), isEven)
+	AssertSliceEq(t, reducers.Collect(Take(odds, 2)), []int{1, 3})
+	AssertSliceEq(t, reducers.Collect(evens), []int{0, 2, 4, 6, 8})
+	// Each side can only be consumed once
+	AssertSliceEq(t, reducers.Collect(odds), []int{})
+}
+
+func TestSplitOneSide(t *testing.T) {
+	isEven := func(x int) bool { return x%2 == 0 }
+	ended := false
+	src := SeqFunc[int](func(yield func(int) bool) {
+		defer func() { ended = true }()
+		for i := 0; yield(i); i++ {
+		}
+	})
+
+	evens, odds := Split(src, isEven)
+	AssertEq(t, reducers.FirstOpt(evens).V, 0)
+	Assert(t, !ended)
+	// Stopping the other side stops the original sequence
+	for range odds.Seq {
+		break
+	}
+	Assert(t, ended)
+
+	// Consuming one side far ahead of the other blocks until it catches up
+	evens, odds = Split(Take(generators.Integers(), 1000), isEven)
+	done := make(chan int)
+	go func() { done <- reducers.Length(odds) }()
+	AssertEq(t, reducers.Length(evens), 500)
+	AssertEq(t, <-done, 500)
+}
+
+func TestTeeSeqs(t *testing.T) {
+	seqs := Tee(Take(generators.Integers(), 100), 2)
+	var sum int
+	var firsts []int
+	done := make(chan struct{})
+	go func() {
+		firsts = reducers.Collect(Take(seqs[1], 3))
+		close(done)
+	}()
+	sum = reducers.Fold(seqs[0], reducers.SumMonoid[int]())
+	<-done
+	AssertEq(t, sum, 4950)
+	AssertSliceEq(t, firsts, []int{0, 1, 2})
+	AssertEq(t, len(Tee(generators.Integers(), -1)), 0)
+}
+
+func TestBroadcast(t *testing.T) {
+	var buf bytes.Buffer
+	var count int
+	var max int
+	Broadcast(
+		Take(generators.Integers(), 50),
+		func(s Seq[int]) { reducers.Consume(Write(Map(s, func(x int) []byte { return []byte{'x'} }), &buf)) },
+		func(s Seq[int]) { count = reducers.Length(s) },
+		func(s Seq[int]) { max = reducers.Max(s) },
+	)
+	AssertEq(t, buf.Len(), 50)
+	AssertEq(t, count, 50)
+	AssertEq(t, max, 49)
+}
+
+type user struct {
+	id   int
+	name string
+}
+
+type order struct {
+	user int
+	item string
+}
+
+func joinStrings(seq Seq2[int, Joined[user, order]]) []string {
+	return reducers.Collect(Map[KeyValue[int, Joined[user, order]]](seq, func(kv KeyValue[int, Joined[user, order]]) string {
+		return fmt.Sprint(kv.Key, ":", kv.Value.Left.V.name, ":", kv.Value.Right.V.item)
+	}))
+}
+
+func TestJoins(t *testing.T) {
+	users := SeqFunc[user](slices.Values([]user{{1, "ann"}, {2, "bob"}, {4, "dee"}}))
+	orders := SeqFunc[order](slices.Values([]order{{1, "a"}, {1, "b"}, {3, "c"}, {4, "d"}}))
+	userID := func(u user) int { return u.id }
+	orderUser := func(o order) int { return o.user }
+
+	inner := []string{"1:ann:a", "1:ann:b", "4:dee:d"}
+	left := []string{"1:ann:a", "1:ann:b", "2:bob:", "4:dee:d"}
+	outer := []string{"1:ann:a", "1:ann:b", "2:bob:", "4:dee:d", "3::c"}
+
+	AssertSliceEq(t, joinStrings(HashJoin(users, orders, userID, orderUser)), inner)
+	AssertSliceEq(t, joinStrings(HashJoin(users, orders, userID, orderUser, LeftJoin)), left)
+	AssertSliceEq(t, joinStrings(HashJoin(users, orders, userID, orderUser, OuterJoin)), outer)
+
+	AssertSliceEq(t, joinStrings(MergeJoin(users, orders, userID, orderUser)), inner)
+	AssertSliceEq(t, joinStrings(MergeJoin(users, orders, userID, orderUser, LeftJoin)), left)
+	// MergeJoin yields unmatched right elements in sorted position
+	AssertSliceEq(t, joinStrings(MergeJoin(users, orders, userID, orderUser, OuterJoin)),
+		[]string{"1:ann:a", "1:ann:b", "2:bob:", "3::c", "4:dee:d"})
+}
+
+func TestMergeJoinInfinite(t *testing.T) {
+	evens := Map(generators.Integers(), func(x int) int { return x * 2 })
+	triples := Map(generators.Integers(), func(x int) int { return x * 3 })
+	both := reducers.Collect(Map[KeyValue[int, Joined[int, int]]](
+		Take[KeyValue[int, Joined[int, int]]](MergeJoin(evens, triples, Identity[int], Identity[int]), 3),
+		func(kv KeyValue[int, Joined[int, int]]) int { return kv.Key },
+	))
+	AssertSliceEq(t, both, []int{0, 6, 12})
+}
+
+func TestSort(t *testing.T) {
+	seq := SeqFunc[int](slices.Values([]int{5, 3, 9, 1, 7, 3}))
+	AssertSliceEq(t, reducers.Collect(Sort(seq, cmp.Compare[int])), []int{1, 3, 3, 5, 7, 9})
+	AssertSliceEq(t, reducers.Collect(TopK(seq, 3, cmp.Compare[int])), []int{9, 7, 5})
+	AssertSliceEq(t, reducers.Collect(BottomK(seq, 2, cmp.Compare[int])), []int{1, 3})
+}
+
+func TestSortSpill(t *testing.T) {
+	old := SpillRunSize
+	SpillRunSize = 100
+	defer func() { SpillRunSize = old }()
+
+	dir := t.TempDir()
+	shuffled := func(n int) Seq[int] {
+		return Map(Take(generators.Integers(), n), func(x int) int { return (x * 7919) % n })
+	}
+	ar := reducers.Collect(SortSpill(shuffled(1050), cmp.Compare[int], GobCodec[int](), dir))
+	AssertSliceEq(t, ar, reducers.Collect(Take(generators.Integers(), 1050)))
+
+	small := reducers.Collect(SortSpill(shuffled(10), cmp.Compare[int], GobCodec[int](), dir))
+	AssertSliceEq(t, small, reducers.Collect(Take(generators.Integers(), 10)))
+
+	AssertEq(t, len(Must(os.ReadDir(dir))), 0)
+}
+
+func TestEncodeJSON(t *testing.T) {
+	var buf bytes.Buffer
+	r := Transduce(
+		generators.Integers(),
+		Chain3(
+			Curry2(Take[int], 3),
+			Curry2(Map, func(x int) map[string]int { return map[string]int{"n": x} }),
+			Curry2(EncodeJSON[map[string]int], io.Writer(&buf)),
+		),
+		reducers.CollectErrs,
+	)
+	AssertEq(t, r.Err, nil)
+	AssertEq(t, buf.String(), "{\"n\":0}\n{\"n\":1}\n{\"n\":2}\n")
+
+	back := reducers.CollectErrs(generators.JSONStream[map[string]int](&buf))
+	AssertEq(t, back.V[2]["n"], 2)
+}
+
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	r := Transduce(
+		generators.Integers(),
+		Chain3(
+			Curry2(Take[int], 2),
+			Curry2(Map, func(x int) []string { return []string{strconv.Itoa(x), "a,b"} }),
+			Curry2(WriteCSV, io.Writer(&buf)),
+		),
+		reducers.CollectErrs,
+	)
+	AssertEq(t, r.Err, nil)
+	AssertEq(t, buf.String(), "0,\"a,b\"\n1,\"a,b\"\n")
+	AssertEq(t, len(reducers.CollectErrs(generators.CSV(&buf)).V), 2)
+}
@@ -0,0 +1,177 @@
+package transducers
+
+import (
+	"time"
+
+	. "github.com/rushsteve1/fp"
+)
+
+// Windowing transducers group adjacent elements together into slices.
+// Every slice they yield is freshly allocated so it is safe to hold onto,
+// and any partial group left over when the sequence ends is flushed.
+
+// Chunk yields slices of count elements, the last of which may be shorter.
+// A count less than 1 is treated as 1.
+func Chunk[T any](seq Seq[T], count int) Seq[[]T] {
+	count = max(count, 1)
+	return SeqFunc[[]T](func(yield func([]T) bool) {
+		buf := make([]T, 0, count)
+		ok := true
+		seq.Seq(func(t T) bool {
+			buf = append(buf, t)
+			if len(buf) < count {
+				return true
+			}
+			ok = yield(buf)
+			buf = make([]T, 0, count)
+			return ok
+		})
+		if ok && len(buf) > 0 {
+			yield(buf)
+		}
+	})
+}
+
+// SlidingWindow yields windows of size elements, starting a new window every
+// step elements. Windows overlap when step is smaller than size and elements
+// are skipped when it is larger.
+// The final window is only yielded if it has elements no other window had.
+// A size or step less than 1 is treated as 1.
+func SlidingWindow[T any](seq Seq[T], size int, step int) Seq[[]T] {
+	size, step = max(size, 1), max(step, 1)
+	return SeqFunc[[]T](func(yield func([]T) bool) {
+		win := make([]T, 0, size)
+		skip := 0
+		// Tracks if the window holds elements that have not been yielded yet
+		pending := false
+		ok := true
+		seq.Seq(func(t T) bool {
+			if skip > 0 {
+				skip--
+				return true
+			}
+			win = append(win, t)
+			pending = true
+			if len(win) < size {
+				return true
+			}
+			ok = yield(win)
+			pending = false
+			if step < size {
+				win = append(make([]T, 0, size), win[step:]...)
+			} else {
+				win = make([]T, 0, size)
+				skip = step - size
+			}
+			return ok
+		})
+		if ok && pending {
+			yield(win)
+		}
+	})
+}
+
+// PartitionBy splits the sequence into runs of elements every time the value
+// returned by f changes. This is the same as Clojure's partition-by.
+func PartitionBy[T any, K comparable](seq Seq[T], f Transform[T, K]) Seq[[]T] {
+	return Map[KeyValue[K, []T]](GroupAdjacent(seq, f), func(kv KeyValue[K, []T]) []T {
+		return kv.Value
+	})
+}
+
+// GroupAdjacent is like [PartitionBy] but also yields the key of each run.
+// Unlike [reducers.GroupBy] keys can occur more than once.
+func GroupAdjacent[T any, K comparable](seq Seq[T], f Transform[T, K]) Seq2[K, []T] {
+	return Seq2Func[K, []T](func(yield func(K, []T) bool) {
+		var run []T
+		var prev K
+		ok := true
+		seq.Seq(func(t T) bool {
+			k := f(t)
+			if len(run) > 0 && k != prev {
+				ok = yield(prev, run)
+				run = nil
+				if !ok {
+					return false
+				}
+			}
+			prev = k
+			run = append(run, t)
+			return true
+		})
+		if ok && len(run) > 0 {
+			yield(prev, run)
+		}
+	})
+}
+
+// BufferTime collects elements into slices that are yielded when either count
+// elements have been collected or wait has passed since the last slice,
+// whichever comes first. Empty slices are never yielded.
+//
+// The sequence is consumed on a separate goroutine so that the timer can fire
+// while waiting on slow sources like [generators.Ticker].
+// When the downstream stops that goroutine will exit the next time the source
+// yields a value.
+// A count less than 1 is treated as 1, and a wait of 0 or less only flushes
+// once count elements have been collected.
+func BufferTime[T any](seq Seq[T], count int, wait time.Duration) Seq[[]T] {
+	count = max(count, 1)
+	return SeqFunc[[]T](func(yield func([]T) bool) {
+		c := make(chan T)
+		done := make(chan struct{})
+		defer close(done)
+
+		go func() {
+			defer close(c)
+			seq.Seq(func(t T) bool {
+				select {
+				case c <- t:
+					return true
+				case <-done:
+					return false
+				}
+			})
+		}()
+
+		// A nil channel never fires
+		var tickC <-chan time.Time
+		resetTick := func() {}
+		if wait > 0 {
+			tick := time.NewTicker(wait)
+			defer tick.Stop()
+			tickC = tick.C
+			resetTick = func() { tick.Reset(wait) }
+		}
+
+		buf := make([]T, 0, count)
+		for {
+			select {
+			case t, ok := <-c:
+				if !ok {
+					if len(buf) > 0 {
+						yield(buf)
+					}
+					return
+				}
+				buf = append(buf, t)
+				if len(buf) < count {
+					continue
+				}
+				if !yield(buf) {
+					return
+				}
+				buf = make([]T, 0, count)
+				resetTick()
+			case <-tickC:
+				if len(buf) == 0 {
+					continue
+				}
+				if !yield(buf) {
+					return
+				}
+				buf = make([]T, 0, count)
+			}
+		}
+	})
+}